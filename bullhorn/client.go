@@ -18,6 +18,12 @@ type Client struct {
 	PlacementService     PlacementService
 	JobSubmissionService JobSubmissionService
 	ClientContactService ClientContactService
+
+	JobSubmissionHistoryService JobSubmissionHistoryService
+	PlacementCommissionService  PlacementCommissionService
+	CorporateUserService        CorporateUserService
+	CustomObjectService         CustomObjectService
+	TearsheetService            TearsheetService
 }
 
 func New(baseURL string) *Client {
@@ -35,10 +41,21 @@ func New(baseURL string) *Client {
 	c.PlacementService = nullPlacementService{}
 	c.JobSubmissionService = nullJobSubmissionService{}
 	c.ClientContactService = nullClientContactService{}
+	c.JobSubmissionHistoryService = nullJobSubmissionHistoryService{}
+	c.PlacementCommissionService = nullPlacementCommissionService{}
+	c.CorporateUserService = nullCorporateUserService{}
+	c.CustomObjectService = nullCustomObjectService{}
+	c.TearsheetService = nullTearsheetService{}
 
 	return c
 }
 
+// SetTransport sends the client's requests through the
+// transport, such as one counting them for metrics
+func (c *Client) SetTransport(t http.RoundTripper) {
+	c.client.Transport = t
+}
+
 func (c *Client) setSession(s Session) {
 	c.token = s.Value.Token
 
@@ -46,6 +63,11 @@ func (c *Client) setSession(s Session) {
 	c.PlacementService = &placementService{client: c, baseURL: s.Value.Endpoint}
 	c.JobSubmissionService = &jobSubmissionService{client: c, baseURL: s.Value.Endpoint}
 	c.ClientContactService = &clientContactService{client: c, baseURL: s.Value.Endpoint}
+	c.JobSubmissionHistoryService = &jobSubmissionHistoryService{client: c, baseURL: s.Value.Endpoint}
+	c.PlacementCommissionService = &placementCommissionService{client: c, baseURL: s.Value.Endpoint}
+	c.CorporateUserService = &corporateUserService{client: c, baseURL: s.Value.Endpoint}
+	c.CustomObjectService = &customObjectService{client: c, baseURL: s.Value.Endpoint}
+	c.TearsheetService = &tearsheetService{client: c, baseURL: s.Value.Endpoint}
 }
 
 func (c *Client) buildURL(baseURL, path string, params url.Values) string {
@@ -87,6 +109,16 @@ func (c *Client) doRequest(req *http.Request, resource interface{}) error {
 	return nil
 }
 
+// doQueryRequest performs the search request decoding the raw
+// entities into raw as well when the query asks for them
+func (c *Client) doQueryRequest(req *http.Request, query SearchQuery, resource interface{}, raw *[]RawEntity) error {
+	if !query.IncludeRaw {
+		return c.doRequest(req, resource)
+	}
+
+	return c.doRequest(req, &rawResponse{resource: resource, raw: raw})
+}
+
 func (c *Client) checkResponse(resp *http.Response) error {
 	if resp.StatusCode == http.StatusOK {
 		return nil
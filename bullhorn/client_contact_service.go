@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/url"
 	"strconv"
-	"strings"
 )
 
 type ClientContactService interface {
@@ -40,11 +39,26 @@ type ClientContact struct {
 	CustomFloat1 float64    `json:"customFloat1"`
 	CustomFloat2 float64    `json:"customFloat2"`
 	CustomFloat3 float64    `json:"customFloat3"`
+
+	// Custom int fields
+	CustomInt1 int `json:"customInt1"`
+	CustomInt2 int `json:"customInt2"`
+	CustomInt3 int `json:"customInt3"`
+
+	// Custom text block fields
+	CustomTextBlock1 string `json:"customTextBlock1"`
+	CustomTextBlock2 string `json:"customTextBlock2"`
+	CustomTextBlock3 string `json:"customTextBlock3"`
+	CustomTextBlock4 string `json:"customTextBlock4"`
+	CustomTextBlock5 string `json:"customTextBlock5"`
+
+	// Raw is only set when the query asks for it
+	Raw RawEntity `json:"-"`
 }
 
 func (c *clientContactService) Search(ctx context.Context, query SearchQuery) (*ClientContacts, error) {
 	q := url.Values{}
-	q.Add("fields", strings.Join(query.Fields, ","))
+	q.Add("fields", BuildFields(query.Fields))
 	q.Add("where", query.Where)
 	q.Add("start", strconv.Itoa(query.Start))
 	q.Add("count", strconv.Itoa(query.Count))
@@ -56,9 +70,14 @@ func (c *clientContactService) Search(ctx context.Context, query SearchQuery) (*
 	}
 
 	contacts := &ClientContacts{}
-	if err := c.client.doRequest(req.WithContext(ctx), contacts); err != nil {
+	raw := []RawEntity{}
+	if err := c.client.doQueryRequest(req.WithContext(ctx), query, contacts, &raw); err != nil {
 		return nil, err
 	}
 
+	for i := range raw {
+		contacts.Items[i].Raw = raw[i]
+	}
+
 	return contacts, nil
 }
@@ -39,4 +39,39 @@ func TestClient_New(t *testing.T) {
 		_, ok := c.JobSubmissionService.(nullJobSubmissionService)
 		assert.Assert(t, ok)
 	})
+
+	t.Run("returns job submission history as null service", func(t *testing.T) {
+		c := New("http://example.com")
+
+		_, ok := c.JobSubmissionHistoryService.(nullJobSubmissionHistoryService)
+		assert.Assert(t, ok)
+	})
+
+	t.Run("returns placement commission as null service", func(t *testing.T) {
+		c := New("http://example.com")
+
+		_, ok := c.PlacementCommissionService.(nullPlacementCommissionService)
+		assert.Assert(t, ok)
+	})
+
+	t.Run("returns corporate user as null service", func(t *testing.T) {
+		c := New("http://example.com")
+
+		_, ok := c.CorporateUserService.(nullCorporateUserService)
+		assert.Assert(t, ok)
+	})
+
+	t.Run("returns custom object as null service", func(t *testing.T) {
+		c := New("http://example.com")
+
+		_, ok := c.CustomObjectService.(nullCustomObjectService)
+		assert.Assert(t, ok)
+	})
+
+	t.Run("returns tearsheet as null service", func(t *testing.T) {
+		c := New("http://example.com")
+
+		_, ok := c.TearsheetService.(nullTearsheetService)
+		assert.Assert(t, ok)
+	})
 }
@@ -0,0 +1,60 @@
+package bullhorn
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+type CorporateUserService interface {
+	Search(context.Context, SearchQuery) (*CorporateUsers, error)
+}
+
+type corporateUserService struct {
+	baseURL string
+	client  *Client
+}
+
+type CorporateUsers struct {
+	Items []CorporateUser `json:"data"`
+}
+
+// CorporateUser is a Bullhorn user such as a recruiter
+// which is referenced as the owner of other entities
+type CorporateUser struct {
+	Person
+
+	Email             string       `json:"email"`
+	Departments       Departments  `json:"departments"`
+	PrimaryDepartment NestedEntity `json:"primaryDepartment"`
+	Enabled           bool         `json:"enabled"`
+}
+
+type Departments struct {
+	Data []NestedEntity `json:"data"`
+}
+
+func (d Departments) Join() string {
+	return Categories(d).Join()
+}
+
+func (c *corporateUserService) Search(ctx context.Context, query SearchQuery) (*CorporateUsers, error) {
+	q := url.Values{}
+	q.Add("fields", BuildFields(query.Fields))
+	q.Add("where", query.Where)
+	q.Add("start", strconv.Itoa(query.Start))
+	q.Add("count", strconv.Itoa(query.Count))
+	q.Add("orderBy", "id")
+
+	req, err := c.client.buildGETRequest(c.client.buildURL(c.baseURL, "/query/CorporateUser", q))
+	if err != nil {
+		return nil, err
+	}
+
+	users := &CorporateUsers{}
+	if err := c.client.doRequest(req.WithContext(ctx), users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -0,0 +1,116 @@
+package bullhorn
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCorporateUserService_Search(t *testing.T) {
+	t.Run("returns corporate users", func(t *testing.T) {
+		want := CorporateUsers{
+			Items: []CorporateUser{
+				{
+					Person: Person{
+						ID:        1,
+						FirstName: "User",
+						LastName:  "AB",
+					},
+					Email: "user.ab@example.com",
+					Departments: Departments{
+						Data: []NestedEntity{
+							{ID: 2, Name: "Sales"},
+							{ID: 3, Name: "London"},
+						},
+					},
+					PrimaryDepartment: NestedEntity{ID: 2, Name: "Sales"},
+					Enabled:           true,
+				},
+				{
+					Person: Person{
+						ID:        2,
+						FirstName: "User",
+						LastName:  "CD",
+					},
+					Email: "user.cd@example.com",
+				},
+			},
+		}
+
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.Header.Get("BhRestToken"), "tok-456")
+			assert.Equal(t, r.URL.Query().Get("fields"), "id,dateAdded,status")
+			assert.Equal(t, r.URL.Query().Get("where"), "isDeleted=false")
+			assert.Equal(t, r.URL.Query().Get("start"), "0")
+			assert.Equal(t, r.URL.Query().Get("count"), "200")
+			assert.Equal(t, r.URL.Query().Get("orderBy"), "id")
+
+			json.NewEncoder(w).Encode(want)
+		})
+
+		defer server.Close()
+
+		srv := &corporateUserService{client: &Client{client: &http.Client{}, token: "tok-456"}, baseURL: server.URL}
+		query := SearchQuery{
+			Fields: []string{"id", "dateAdded", "status"},
+			Where:  "isDeleted=false",
+			Start:  0,
+			Count:  200,
+		}
+
+		got, err := srv.Search(context.Background(), query)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, &want)
+	})
+
+	t.Run("returns error when request fails", func(t *testing.T) {
+		srv := &corporateUserService{client: New("")}
+		_, err := srv.Search(context.Background(), SearchQuery{})
+		assert.ErrorContains(t, err, "unsupported protocol scheme")
+	})
+
+	t.Run("returns error when request building fail", func(t *testing.T) {
+		srv := &corporateUserService{client: &Client{}, baseURL: string([]byte{0x7f})}
+		_, err := srv.Search(context.Background(), SearchQuery{})
+		assert.ErrorContains(t, err, "net/url: invalid control character in URL")
+	})
+
+	t.Run("returns error when non 200 response code", func(t *testing.T) {
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, "error invalid query")
+		})
+		defer server.Close()
+
+		srv := &corporateUserService{client: New(""), baseURL: server.URL}
+
+		_, err := srv.Search(context.Background(), SearchQuery{})
+		want := &Error{
+			StatusCode:  http.StatusBadRequest,
+			RequestPath: "/query/CorporateUser",
+			Message:     "error invalid query",
+		}
+		assert.DeepEqual(t, err, want)
+	})
+}
+
+func TestDepartments_Join(t *testing.T) {
+	t.Run("returns an empty string", func(t *testing.T) {
+		assert.Equal(t, Departments{}.Join(), "")
+	})
+
+	t.Run("returns sorted department names", func(t *testing.T) {
+		d := Departments{
+			Data: []NestedEntity{
+				{Name: "Sales"},
+				{Name: "London"},
+			},
+		}
+
+		assert.Equal(t, d.Join(), "London ; Sales")
+	})
+}
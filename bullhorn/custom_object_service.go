@@ -0,0 +1,97 @@
+package bullhorn
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+var customObjectRegexp = regexp.MustCompile(`^(Person|JobOrder|Placement|ClientCorporation|Opportunity)CustomObjectInstance(\d{1,2})$`)
+
+// customObjectParents maps the custom object prefix
+// to the field referencing the entity it belongs to
+var customObjectParents = map[string]string{
+	"Person":            "person",
+	"JobOrder":          "jobOrder",
+	"Placement":         "placement",
+	"ClientCorporation": "clientCorporation",
+	"Opportunity":       "opportunity",
+}
+
+// CustomObjectService searches any custom object instance entity, such as
+// PersonCustomObjectInstance1, as the fields used differ for each tenant
+type CustomObjectService interface {
+	Search(_ context.Context, entity string, query SearchQuery) (*CustomObjectInstances, error)
+}
+
+type customObjectService struct {
+	baseURL string
+	client  *Client
+}
+
+type CustomObjectInstances struct {
+	Items []CustomObjectInstance `json:"data"`
+}
+
+// CustomObjectInstance holds the raw field values keyed by the
+// Bullhorn field name such as text1, date1 and int1
+type CustomObjectInstance map[string]interface{}
+
+// CustomObjectParentField returns the field which references the entity the
+// custom object belongs to, it returns an error when it isn't a custom object
+func CustomObjectParentField(entity string) (string, error) {
+	parts := customObjectRegexp.FindStringSubmatch(entity)
+	if parts == nil {
+		return "", fmt.Errorf("unknown custom object %q, expected for example PersonCustomObjectInstance1", entity)
+	}
+
+	return customObjectParents[parts[1]], nil
+}
+
+func (c CustomObjectInstance) Float(field string) float64 {
+	v, _ := c[field].(float64)
+	return v
+}
+
+func (c CustomObjectInstance) String(field string) string {
+	v, _ := c[field].(string)
+	return v
+}
+
+func (c CustomObjectInstance) EpochMilli(field string) EpochMilli {
+	return EpochMilli(c.Float(field))
+}
+
+// NestedID returns the id of an associated entity such as the parent
+func (c CustomObjectInstance) NestedID(field string) int {
+	nested, _ := c[field].(map[string]interface{})
+	id, _ := nested["id"].(float64)
+	return int(id)
+}
+
+func (c *customObjectService) Search(ctx context.Context, entity string, query SearchQuery) (*CustomObjectInstances, error) {
+	if _, err := CustomObjectParentField(entity); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Add("fields", BuildFields(query.Fields))
+	q.Add("where", query.Where)
+	q.Add("start", strconv.Itoa(query.Start))
+	q.Add("count", strconv.Itoa(query.Count))
+	q.Add("orderBy", "-id")
+
+	req, err := c.client.buildGETRequest(c.client.buildURL(c.baseURL, "/query/"+entity, q))
+	if err != nil {
+		return nil, err
+	}
+
+	instances := &CustomObjectInstances{}
+	if err := c.client.doRequest(req.WithContext(ctx), instances); err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
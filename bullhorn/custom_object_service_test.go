@@ -0,0 +1,152 @@
+package bullhorn
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCustomObjectService_Search(t *testing.T) {
+	t.Run("returns custom object instances", func(t *testing.T) {
+		want := CustomObjectInstances{
+			Items: []CustomObjectInstance{
+				{
+					"id":     float64(2),
+					"person": map[string]interface{}{"id": float64(44)},
+					"text1":  "Passed",
+					"date1":  float64(1659190221000),
+					"int1":   float64(3),
+				},
+				{
+					"id":    float64(1),
+					"text1": "Failed",
+				},
+			},
+		}
+
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.URL.Path, "/query/PersonCustomObjectInstance1")
+			assert.Equal(t, r.Header.Get("BhRestToken"), "tok-456")
+			assert.Equal(t, r.URL.Query().Get("fields"), "id,person,text1,date1,int1")
+			assert.Equal(t, r.URL.Query().Get("where"), "id>0")
+			assert.Equal(t, r.URL.Query().Get("start"), "0")
+			assert.Equal(t, r.URL.Query().Get("count"), "200")
+			assert.Equal(t, r.URL.Query().Get("orderBy"), "-id")
+
+			json.NewEncoder(w).Encode(want)
+		})
+
+		defer server.Close()
+
+		srv := &customObjectService{client: &Client{client: &http.Client{}, token: "tok-456"}, baseURL: server.URL}
+		query := SearchQuery{
+			Fields: []string{"id", "person", "text1", "date1", "int1"},
+			Where:  "id>0",
+			Start:  0,
+			Count:  200,
+		}
+
+		got, err := srv.Search(context.Background(), "PersonCustomObjectInstance1", query)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, &want)
+	})
+
+	t.Run("returns error when entity isn't a custom object", func(t *testing.T) {
+		srv := &customObjectService{client: New("")}
+		_, err := srv.Search(context.Background(), "Candidate", SearchQuery{})
+		assert.Error(t, err, `unknown custom object "Candidate", expected for example PersonCustomObjectInstance1`)
+	})
+
+	t.Run("returns error when request fails", func(t *testing.T) {
+		srv := &customObjectService{client: New("")}
+		_, err := srv.Search(context.Background(), "PersonCustomObjectInstance1", SearchQuery{})
+		assert.ErrorContains(t, err, "unsupported protocol scheme")
+	})
+
+	t.Run("returns error when request building fail", func(t *testing.T) {
+		srv := &customObjectService{client: &Client{}, baseURL: string([]byte{0x7f})}
+		_, err := srv.Search(context.Background(), "PersonCustomObjectInstance1", SearchQuery{})
+		assert.ErrorContains(t, err, "net/url: invalid control character in URL")
+	})
+
+	t.Run("returns error when non 200 response code", func(t *testing.T) {
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, "error invalid query")
+		})
+		defer server.Close()
+
+		srv := &customObjectService{client: New(""), baseURL: server.URL}
+
+		_, err := srv.Search(context.Background(), "JobOrderCustomObjectInstance2", SearchQuery{})
+		want := &Error{
+			StatusCode:  http.StatusBadRequest,
+			RequestPath: "/query/JobOrderCustomObjectInstance2",
+			Message:     "error invalid query",
+		}
+		assert.DeepEqual(t, err, want)
+	})
+}
+
+func TestCustomObjectParentField(t *testing.T) {
+	specs := []struct {
+		entity  string
+		want    string
+		wantErr string
+	}{
+		{entity: "PersonCustomObjectInstance1", want: "person"},
+		{entity: "JobOrderCustomObjectInstance10", want: "jobOrder"},
+		{entity: "PlacementCustomObjectInstance3", want: "placement"},
+		{entity: "ClientCorporationCustomObjectInstance2", want: "clientCorporation"},
+		{entity: "OpportunityCustomObjectInstance5", want: "opportunity"},
+		{
+			entity:  "CandidateCustomObjectInstance1",
+			wantErr: `unknown custom object "CandidateCustomObjectInstance1", expected for example PersonCustomObjectInstance1`,
+		},
+		{
+			entity:  "PersonCustomObjectInstance",
+			wantErr: `unknown custom object "PersonCustomObjectInstance", expected for example PersonCustomObjectInstance1`,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.entity, func(t *testing.T) {
+			got, err := CustomObjectParentField(spec.entity)
+			if spec.wantErr != "" {
+				assert.Error(t, err, spec.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, got, spec.want)
+		})
+	}
+}
+
+func TestCustomObjectInstance(t *testing.T) {
+	c := CustomObjectInstance{
+		"id":     float64(2),
+		"person": map[string]interface{}{"id": float64(44)},
+		"text1":  "Passed",
+		"date1":  float64(1659190221000),
+		"float1": 1.5,
+	}
+
+	t.Run("returns field values", func(t *testing.T) {
+		assert.Equal(t, c.String("text1"), "Passed")
+		assert.Equal(t, c.Float("float1"), 1.5)
+		assert.Equal(t, c.EpochMilli("date1"), EpochMilli(1659190221000))
+		assert.Equal(t, c.NestedID("person"), 44)
+	})
+
+	t.Run("returns zero values for missing fields", func(t *testing.T) {
+		assert.Equal(t, c.String("text2"), "")
+		assert.Equal(t, c.Float("float2"), float64(0))
+		assert.Equal(t, c.EpochMilli("date2"), EpochMilli(0))
+		assert.Equal(t, c.NestedID("jobOrder"), 0)
+	})
+}
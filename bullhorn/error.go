@@ -1,6 +1,11 @@
 package bullhorn
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
 
 type Error struct {
 	StatusCode  int
@@ -14,3 +19,22 @@ func (e *Error) Error() string {
 
 	return msg + " " + extra
 }
+
+// RedactedError returns the message of the error leaving out the query of the
+// url of a failed request, as logging in sends the password in it
+func RedactedError(err error) string {
+	text := err.Error()
+
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return text
+	}
+
+	u, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil || u.RawQuery == "" {
+		return text
+	}
+
+	u.RawQuery = ""
+	return strings.ReplaceAll(text, urlErr.URL, u.String())
+}
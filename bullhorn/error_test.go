@@ -1,6 +1,9 @@
 package bullhorn
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -15,3 +18,10 @@ func TestError_Error(t *testing.T) {
 
 	assert.Equal(t, err.Error(), `Bullhorn error: missing where query got response code 400 for request path "some/path"`)
 }
+
+func TestRedactedError(t *testing.T) {
+	err := &url.Error{Op: "Get", URL: "https://bullhorn.example.com/login?username=a&password=secret", Err: errors.New("timeout")}
+
+	assert.Equal(t, RedactedError(fmt.Errorf("logging in: %w", err)), `logging in: Get "https://bullhorn.example.com/login": timeout`)
+	assert.Equal(t, RedactedError(errors.New("timeout")), "timeout")
+}
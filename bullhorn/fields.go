@@ -0,0 +1,163 @@
+package bullhorn
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// defaultNestedFields are the fields asked for when an association is
+// requested on its own as well as through a dotted path. Bullhorn only
+// returns what is listed once the association has nested fields, so the
+// fields the typed structs rely on have to be listed explicitly
+var defaultNestedFields = map[string][]string{
+	"address":           {"address1", "address2", "city", "state", "zip", "countryID"},
+	"candidate":         {"id", "firstName", "lastName"},
+	"categories":        {"id", "name"},
+	"clientCorporation": {"id", "name"},
+	"jobOrder":          {"id", "title"},
+	"owner":             {"id", "firstName", "lastName"},
+	"owners":            {"id", "firstName", "lastName"},
+}
+
+type fieldNode struct {
+	name      string
+	requested bool
+	children  []*fieldNode
+}
+
+func (n *fieldNode) child(name string) *fieldNode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+
+	c := &fieldNode{name: name}
+	n.children = append(n.children, c)
+	return c
+}
+
+func (n *fieldNode) String() string {
+	if len(n.children) == 0 {
+		return n.name
+	}
+
+	subFields := []string{}
+	if n.requested {
+		defaults, ok := defaultNestedFields[n.name]
+		if !ok {
+			defaults = []string{"id"}
+		}
+
+		for _, d := range defaults {
+			if !n.hasChild(d) {
+				subFields = append(subFields, d)
+			}
+		}
+	}
+
+	for _, c := range n.children {
+		subFields = append(subFields, c.String())
+	}
+
+	return n.name + "(" + strings.Join(subFields, ",") + ")"
+}
+
+func (n *fieldNode) hasChild(name string) bool {
+	for _, c := range n.children {
+		if c.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BuildFields turns the query fields into the Bullhorn fields syntax.
+// Dotted paths such as jobOrder.clientCorporation.name are grouped by
+// their association into jobOrder(clientCorporation(name)), fields
+// already using the nested syntax are passed through as they are
+func BuildFields(fields []string) string {
+	root := &fieldNode{}
+
+	for _, f := range fields {
+		if strings.Contains(f, "(") {
+			root.children = append(root.children, &fieldNode{name: f})
+			continue
+		}
+
+		node := root
+		for _, part := range strings.Split(f, ".") {
+			node = node.child(part)
+		}
+
+		node.requested = true
+	}
+
+	out := []string{}
+	for _, c := range root.children {
+		out = append(out, c.String())
+	}
+
+	return strings.Join(out, ",")
+}
+
+// RawEntity is an entity as returned by Bullhorn before being decoded
+// into its struct, used to reach fields the struct doesn't declare
+type RawEntity map[string]interface{}
+
+// Lookup returns the value at the dotted path. To-many associations
+// return a slice of the values found in each associated entity
+func (r RawEntity) Lookup(path string) interface{} {
+	return lookupPath(map[string]interface{}(r), strings.Split(path, "."))
+}
+
+func lookupPath(v interface{}, parts []string) interface{} {
+	if len(parts) == 0 {
+		return v
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if items, ok := m["data"].([]interface{}); ok {
+		if _, isField := m[parts[0]]; !isField {
+			vals := []interface{}{}
+			for _, item := range items {
+				if val := lookupPath(item, parts); val != nil {
+					vals = append(vals, val)
+				}
+			}
+
+			return vals
+		}
+	}
+
+	return lookupPath(m[parts[0]], parts[1:])
+}
+
+// rawResponse decodes a search response into the typed resource and
+// a second time into the raw entities
+type rawResponse struct {
+	resource interface{}
+	raw      *[]RawEntity
+}
+
+func (r *rawResponse) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, r.resource); err != nil {
+		return err
+	}
+
+	raw := struct {
+		Data []RawEntity `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*r.raw = raw.Data
+	return nil
+}
@@ -0,0 +1,91 @@
+package bullhorn
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBuildFields(t *testing.T) {
+	specs := []struct {
+		name string
+		in   []string
+		want string
+	}{
+		{
+			name: "returns plain fields as they are",
+			in:   []string{"id", "title", "owner"},
+			want: "id,title,owner",
+		},
+		{
+			name: "groups dotted paths by association",
+			in:   []string{"id", "jobOrder.title", "jobOrder.clientCorporation.name", "candidate.source"},
+			want: "id,jobOrder(title,clientCorporation(name)),candidate(source)",
+		},
+		{
+			name: "keeps the default fields when the association is also requested",
+			in:   []string{"id", "owner", "owner.email"},
+			want: "id,owner(id,firstName,lastName,email)",
+		},
+		{
+			name: "falls back to the id for unknown associations",
+			in:   []string{"sendingUser", "sendingUser.email"},
+			want: "sendingUser(id,email)",
+		},
+		{
+			name: "doesn't repeat a default field requested as a path",
+			in:   []string{"jobOrder", "jobOrder.title"},
+			want: "jobOrder(id,title)",
+		},
+		{
+			name: "passes nested syntax through",
+			in:   []string{"id", "placement(id,fee,status)"},
+			want: "id,placement(id,fee,status)",
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			assert.Equal(t, BuildFields(spec.in), spec.want)
+		})
+	}
+}
+
+func TestRawEntity_Lookup(t *testing.T) {
+	raw := RawEntity{
+		"id": float64(12),
+		"jobOrder": map[string]interface{}{
+			"clientCorporation": map[string]interface{}{
+				"name": "GeckoOrg",
+			},
+		},
+		"categories": map[string]interface{}{
+			"total": float64(2),
+			"data": []interface{}{
+				map[string]interface{}{"name": "Category A"},
+				map[string]interface{}{"name": "Category B"},
+			},
+		},
+	}
+
+	t.Run("returns a top level value", func(t *testing.T) {
+		assert.Equal(t, raw.Lookup("id"), float64(12))
+	})
+
+	t.Run("returns a nested value", func(t *testing.T) {
+		assert.Equal(t, raw.Lookup("jobOrder.clientCorporation.name"), "GeckoOrg")
+	})
+
+	t.Run("returns each value of a to-many association", func(t *testing.T) {
+		assert.DeepEqual(t, raw.Lookup("categories.name"), []interface{}{"Category A", "Category B"})
+	})
+
+	t.Run("returns the to-many total", func(t *testing.T) {
+		assert.Equal(t, raw.Lookup("categories.total"), float64(2))
+	})
+
+	t.Run("returns nil when the path is missing", func(t *testing.T) {
+		assert.Assert(t, raw.Lookup("candidate.source") == nil)
+		assert.Assert(t, raw.Lookup("id.value") == nil)
+	})
+}
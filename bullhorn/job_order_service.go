@@ -35,6 +35,53 @@ type JobOrder struct {
 	Owner          Person       `json:"owner"`
 	Client         NestedEntity `json:"clientCorporation"`
 	IsOpen         bool         `json:"isOpen"`
+
+	// Custom date fields
+	CustomDate1 EpochMilli `json:"customDate1"`
+	CustomDate2 EpochMilli `json:"customDate2"`
+	CustomDate3 EpochMilli `json:"customDate3"`
+
+	// Custom text fields
+	CustomText1  string `json:"customText1"`
+	CustomText2  string `json:"customText2"`
+	CustomText3  string `json:"customText3"`
+	CustomText4  string `json:"customText4"`
+	CustomText5  string `json:"customText5"`
+	CustomText6  string `json:"customText6"`
+	CustomText7  string `json:"customText7"`
+	CustomText8  string `json:"customText8"`
+	CustomText9  string `json:"customText9"`
+	CustomText10 string `json:"customText10"`
+	CustomText11 string `json:"customText11"`
+	CustomText12 string `json:"customText12"`
+	CustomText13 string `json:"customText13"`
+	CustomText14 string `json:"customText14"`
+	CustomText15 string `json:"customText15"`
+	CustomText16 string `json:"customText16"`
+	CustomText17 string `json:"customText17"`
+	CustomText18 string `json:"customText18"`
+	CustomText19 string `json:"customText19"`
+	CustomText20 string `json:"customText20"`
+
+	// Custom float fields
+	CustomFloat1 float64 `json:"customFloat1"`
+	CustomFloat2 float64 `json:"customFloat2"`
+	CustomFloat3 float64 `json:"customFloat3"`
+
+	// Custom int fields
+	CustomInt1 int `json:"customInt1"`
+	CustomInt2 int `json:"customInt2"`
+	CustomInt3 int `json:"customInt3"`
+
+	// Custom text block fields
+	CustomTextBlock1 string `json:"customTextBlock1"`
+	CustomTextBlock2 string `json:"customTextBlock2"`
+	CustomTextBlock3 string `json:"customTextBlock3"`
+	CustomTextBlock4 string `json:"customTextBlock4"`
+	CustomTextBlock5 string `json:"customTextBlock5"`
+
+	// Raw is only set when the query asks for it
+	Raw RawEntity `json:"-"`
 }
 
 type Categories struct {
@@ -56,7 +103,7 @@ func (c Categories) Join() string {
 
 func (j *jobOrderService) Search(ctx context.Context, query SearchQuery) (*JobOrders, error) {
 	q := url.Values{}
-	q.Add("fields", strings.Join(query.Fields, ","))
+	q.Add("fields", BuildFields(query.Fields))
 	q.Add("where", query.Where)
 	q.Add("start", strconv.Itoa(query.Start))
 	q.Add("count", strconv.Itoa(query.Count))
@@ -67,9 +114,14 @@ func (j *jobOrderService) Search(ctx context.Context, query SearchQuery) (*JobOr
 	}
 
 	jobs := &JobOrders{}
-	if err := j.client.doRequest(req.WithContext(ctx), jobs); err != nil {
+	raw := []RawEntity{}
+	if err := j.client.doQueryRequest(req.WithContext(ctx), query, jobs, &raw); err != nil {
 		return nil, err
 	}
 
+	for i := range raw {
+		jobs.Items[i].Raw = raw[i]
+	}
+
 	return jobs, nil
 }
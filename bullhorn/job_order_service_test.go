@@ -78,6 +78,28 @@ func TestJobOrderService_Search(t *testing.T) {
 		assert.DeepEqual(t, got, &want)
 	})
 
+	t.Run("returns job orders with the raw entity when requested", func(t *testing.T) {
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.URL.Query().Get("fields"), "id,clientCorporation(id,name,address(state))")
+			io.WriteString(w, `{"data":[{"id":12,"clientCorporation":{"id":3,"name":"GeckoOrg","address":{"state":"CA"}}}]}`)
+		})
+
+		defer server.Close()
+
+		job := &jobOrderService{client: &Client{client: &http.Client{}}, baseURL: server.URL}
+		query := SearchQuery{
+			Fields:     []string{"id", "clientCorporation", "clientCorporation.address.state"},
+			IncludeRaw: true,
+		}
+
+		got, err := job.Search(context.Background(), query)
+		assert.NilError(t, err)
+		assert.Equal(t, len(got.Items), 1)
+		assert.Equal(t, got.Items[0].ID, 12)
+		assert.Equal(t, got.Items[0].Client.Name, "GeckoOrg")
+		assert.Equal(t, got.Items[0].Raw.Lookup("clientCorporation.address.state"), "CA")
+	})
+
 	t.Run("returns error when request fails", func(t *testing.T) {
 		jos := &jobOrderService{client: New("")}
 
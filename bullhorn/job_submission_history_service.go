@@ -0,0 +1,52 @@
+package bullhorn
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+type JobSubmissionHistoryService interface {
+	Search(context.Context, SearchQuery) (*JobSubmissionHistories, error)
+}
+
+type jobSubmissionHistoryService struct {
+	baseURL string
+	client  *Client
+}
+
+type JobSubmissionHistories struct {
+	Items []JobSubmissionHistory `json:"data"`
+}
+
+// JobSubmissionHistory is a single status change of a job submission
+type JobSubmissionHistory struct {
+	ID int `json:"id"`
+
+	DateAdded EpochMilli `json:"dateAdded"`
+
+	JobSubmission NestedEntity `json:"jobSubmission"`
+	ModifyingUser Person       `json:"modifyingUser"`
+	Status        string       `json:"status"`
+}
+
+func (j *jobSubmissionHistoryService) Search(ctx context.Context, query SearchQuery) (*JobSubmissionHistories, error) {
+	q := url.Values{}
+	q.Add("fields", BuildFields(query.Fields))
+	q.Add("where", query.Where)
+	q.Add("start", strconv.Itoa(query.Start))
+	q.Add("count", strconv.Itoa(query.Count))
+	q.Add("orderBy", "-dateAdded")
+
+	req, err := j.client.buildGETRequest(j.client.buildURL(j.baseURL, "/query/JobSubmissionHistory", q))
+	if err != nil {
+		return nil, err
+	}
+
+	histories := &JobSubmissionHistories{}
+	if err := j.client.doRequest(req.WithContext(ctx), histories); err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/url"
 	"strconv"
-	"strings"
 )
 
 type JobSubmissionService interface {
@@ -44,11 +43,28 @@ type JobSubmission struct {
 	CustomFloat3 float64 `json:"customFloat3"`
 	CustomFloat4 float64 `json:"customFloat4"`
 	CustomFloat5 float64 `json:"customFloat5"`
+
+	// Custom int fields
+	CustomInt1 int `json:"customInt1"`
+	CustomInt2 int `json:"customInt2"`
+	CustomInt3 int `json:"customInt3"`
+	CustomInt4 int `json:"customInt4"`
+	CustomInt5 int `json:"customInt5"`
+
+	// Custom text block fields
+	CustomTextBlock1 string `json:"customTextBlock1"`
+	CustomTextBlock2 string `json:"customTextBlock2"`
+	CustomTextBlock3 string `json:"customTextBlock3"`
+	CustomTextBlock4 string `json:"customTextBlock4"`
+	CustomTextBlock5 string `json:"customTextBlock5"`
+
+	// Raw is only set when the query asks for it
+	Raw RawEntity `json:"-"`
 }
 
 func (j *jobSubmissionService) Search(ctx context.Context, query SearchQuery) (*JobSubmissions, error) {
 	q := url.Values{}
-	q.Add("fields", strings.Join(query.Fields, ","))
+	q.Add("fields", BuildFields(query.Fields))
 	q.Add("where", query.Where)
 	q.Add("start", strconv.Itoa(query.Start))
 	q.Add("count", strconv.Itoa(query.Count))
@@ -60,9 +76,14 @@ func (j *jobSubmissionService) Search(ctx context.Context, query SearchQuery) (*
 	}
 
 	submissions := &JobSubmissions{}
-	if err := j.client.doRequest(req.WithContext(ctx), submissions); err != nil {
+	raw := []RawEntity{}
+	if err := j.client.doQueryRequest(req.WithContext(ctx), query, submissions, &raw); err != nil {
 		return nil, err
 	}
 
+	for i := range raw {
+		submissions.Items[i].Raw = raw[i]
+	}
+
 	return submissions, nil
 }
@@ -11,6 +11,11 @@ type nullJobOrderService struct{ JobOrderService }
 type nullPlacementService struct{ PlacementService }
 type nullJobSubmissionService struct{ JobSubmissionService }
 type nullClientContactService struct{ ClientContactService }
+type nullJobSubmissionHistoryService struct{ JobSubmissionHistoryService }
+type nullPlacementCommissionService struct{ PlacementCommissionService }
+type nullCorporateUserService struct{ CorporateUserService }
+type nullCustomObjectService struct{ CustomObjectService }
+type nullTearsheetService struct{ TearsheetService }
 
 func (nullJobOrderService) Search(context.Context, SearchQuery) (*JobOrders, error) {
 	return nil, errMissingSession
@@ -27,3 +32,23 @@ func (nullJobSubmissionService) Search(context.Context, SearchQuery) (*JobSubmis
 func (nullClientContactService) Search(context.Context, SearchQuery) (*ClientContacts, error) {
 	return nil, errMissingSession
 }
+
+func (nullJobSubmissionHistoryService) Search(context.Context, SearchQuery) (*JobSubmissionHistories, error) {
+	return nil, errMissingSession
+}
+
+func (nullPlacementCommissionService) Search(context.Context, SearchQuery) (*PlacementCommissions, error) {
+	return nil, errMissingSession
+}
+
+func (nullCorporateUserService) Search(context.Context, SearchQuery) (*CorporateUsers, error) {
+	return nil, errMissingSession
+}
+
+func (nullCustomObjectService) Search(context.Context, string, SearchQuery) (*CustomObjectInstances, error) {
+	return nil, errMissingSession
+}
+
+func (nullTearsheetService) Search(context.Context, SearchQuery) (*Tearsheets, error) {
+	return nil, errMissingSession
+}
@@ -31,4 +31,34 @@ func TestNullSessionService(t *testing.T) {
 		_, err := n.Search(context.Background(), SearchQuery{})
 		assert.Error(t, err, errMissingSession.Error())
 	})
+
+	t.Run("returns a missing session error when job submission history", func(t *testing.T) {
+		n := nullJobSubmissionHistoryService{}
+		_, err := n.Search(context.Background(), SearchQuery{})
+		assert.Error(t, err, errMissingSession.Error())
+	})
+
+	t.Run("returns a missing session error when placement commission", func(t *testing.T) {
+		n := nullPlacementCommissionService{}
+		_, err := n.Search(context.Background(), SearchQuery{})
+		assert.Error(t, err, errMissingSession.Error())
+	})
+
+	t.Run("returns a missing session error when corporate user", func(t *testing.T) {
+		n := nullCorporateUserService{}
+		_, err := n.Search(context.Background(), SearchQuery{})
+		assert.Error(t, err, errMissingSession.Error())
+	})
+
+	t.Run("returns a missing session error when custom object", func(t *testing.T) {
+		n := nullCustomObjectService{}
+		_, err := n.Search(context.Background(), "PersonCustomObjectInstance1", SearchQuery{})
+		assert.Error(t, err, errMissingSession.Error())
+	})
+
+	t.Run("returns a missing session error when tearsheet", func(t *testing.T) {
+		n := nullTearsheetService{}
+		_, err := n.Search(context.Background(), SearchQuery{})
+		assert.Error(t, err, errMissingSession.Error())
+	})
 }
@@ -0,0 +1,55 @@
+package bullhorn
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+type PlacementCommissionService interface {
+	Search(context.Context, SearchQuery) (*PlacementCommissions, error)
+}
+
+type placementCommissionService struct {
+	baseURL string
+	client  *Client
+}
+
+type PlacementCommissions struct {
+	Items []PlacementCommission `json:"data"`
+}
+
+// PlacementCommission is the share of a placement
+// fee credited to a single corporate user
+type PlacementCommission struct {
+	ID int `json:"id"`
+
+	DateAdded EpochMilli `json:"dateAdded"`
+
+	CommissionPercentage float64   `json:"commissionPercentage"`
+	Placement            Placement `json:"placement"`
+	Role                 string    `json:"role"`
+	Status               string    `json:"status"`
+	User                 Person    `json:"user"`
+}
+
+func (p *placementCommissionService) Search(ctx context.Context, query SearchQuery) (*PlacementCommissions, error) {
+	q := url.Values{}
+	q.Add("fields", BuildFields(query.Fields))
+	q.Add("where", query.Where)
+	q.Add("start", strconv.Itoa(query.Start))
+	q.Add("count", strconv.Itoa(query.Count))
+	q.Add("orderBy", "-id")
+
+	req, err := p.client.buildGETRequest(p.client.buildURL(p.baseURL, "/query/PlacementCommission", q))
+	if err != nil {
+		return nil, err
+	}
+
+	commissions := &PlacementCommissions{}
+	if err := p.client.doRequest(req.WithContext(ctx), commissions); err != nil {
+		return nil, err
+	}
+
+	return commissions, nil
+}
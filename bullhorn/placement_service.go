@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/url"
 	"strconv"
-	"strings"
 )
 
 type PlacementService interface {
@@ -130,19 +129,77 @@ type Placement struct {
 	CustomFloat22 float64 `json:"customFloat22"`
 	CustomFloat23 float64 `json:"customFloat23"`
 
-	EmployeeType     string       `json:"employeeType"`
-	EmploymentType   string       `json:"employmentType"`
-	Fee              float64      `json:"fee"`
-	JobOrder         NestedEntity `json:"jobOrder"`
-	OnboardingStatus string       `json:"onboardingStatus"`
-	ReferralFee      float64      `json:"referralFee"`
-	ReferralFeeType  string       `json:"referralFeeType"`
-	Status           string       `json:"status"`
+	// Custom int fields
+	CustomInt1  int `json:"customInt1"`
+	CustomInt2  int `json:"customInt2"`
+	CustomInt3  int `json:"customInt3"`
+	CustomInt4  int `json:"customInt4"`
+	CustomInt5  int `json:"customInt5"`
+	CustomInt6  int `json:"customInt6"`
+	CustomInt7  int `json:"customInt7"`
+	CustomInt8  int `json:"customInt8"`
+	CustomInt9  int `json:"customInt9"`
+	CustomInt10 int `json:"customInt10"`
+	CustomInt11 int `json:"customInt11"`
+	CustomInt12 int `json:"customInt12"`
+	CustomInt13 int `json:"customInt13"`
+	CustomInt14 int `json:"customInt14"`
+	CustomInt15 int `json:"customInt15"`
+	CustomInt16 int `json:"customInt16"`
+	CustomInt17 int `json:"customInt17"`
+	CustomInt18 int `json:"customInt18"`
+	CustomInt19 int `json:"customInt19"`
+	CustomInt20 int `json:"customInt20"`
+	CustomInt21 int `json:"customInt21"`
+	CustomInt22 int `json:"customInt22"`
+	CustomInt23 int `json:"customInt23"`
+
+	// Custom text block fields
+	CustomTextBlock1  string `json:"customTextBlock1"`
+	CustomTextBlock2  string `json:"customTextBlock2"`
+	CustomTextBlock3  string `json:"customTextBlock3"`
+	CustomTextBlock4  string `json:"customTextBlock4"`
+	CustomTextBlock5  string `json:"customTextBlock5"`
+	CustomTextBlock6  string `json:"customTextBlock6"`
+	CustomTextBlock7  string `json:"customTextBlock7"`
+	CustomTextBlock8  string `json:"customTextBlock8"`
+	CustomTextBlock9  string `json:"customTextBlock9"`
+	CustomTextBlock10 string `json:"customTextBlock10"`
+
+	EmployeeType     string            `json:"employeeType"`
+	EmploymentType   string            `json:"employmentType"`
+	Fee              float64           `json:"fee"`
+	FlatFee          float64           `json:"flatFee"`
+	JobOrder         PlacementJobOrder `json:"jobOrder"`
+	OnboardingStatus string            `json:"onboardingStatus"`
+	ReferralFee      float64           `json:"referralFee"`
+	ReferralFeeType  string            `json:"referralFeeType"`
+	Status           string            `json:"status"`
+
+	Salary         float64 `json:"salary"`
+	PayRate        float64 `json:"payRate"`
+	ClientBillRate float64 `json:"clientBillRate"`
+	HoursPerDay    float64 `json:"hoursPerDay"`
+	DurationWeeks  float64 `json:"durationWeeks"`
+
+	Candidate Person `json:"candidate"`
+	Owner     Person `json:"owner"`
+
+	// Raw is only set when the query asks for it
+	Raw RawEntity `json:"-"`
+}
+
+// PlacementJobOrder is the job order of a placement
+// along with the client corporation it's for
+type PlacementJobOrder struct {
+	NestedEntity
+
+	ClientCorporation NestedEntity `json:"clientCorporation"`
 }
 
 func (j *placementService) Search(ctx context.Context, query SearchQuery) (*Placements, error) {
 	q := url.Values{}
-	q.Add("fields", strings.Join(query.Fields, ","))
+	q.Add("fields", BuildFields(query.Fields))
 	q.Add("where", query.Where)
 	q.Add("start", strconv.Itoa(query.Start))
 	q.Add("count", strconv.Itoa(query.Count))
@@ -153,9 +210,14 @@ func (j *placementService) Search(ctx context.Context, query SearchQuery) (*Plac
 	}
 
 	placements := &Placements{}
-	if err := j.client.doRequest(req.WithContext(ctx), placements); err != nil {
+	raw := []RawEntity{}
+	if err := j.client.doQueryRequest(req.WithContext(ctx), query, placements, &raw); err != nil {
 		return nil, err
 	}
 
+	for i := range raw {
+		placements.Items[i].Raw = raw[i]
+	}
+
 	return placements, nil
 }
@@ -125,9 +125,9 @@ func TestPlacementService_Search(t *testing.T) {
 					EmployeeType:   "1",
 					EmploymentType: "Contract",
 					Fee:            123,
-					JobOrder: NestedEntity{
-						ID:    99,
-						Title: "Job Title ABC",
+					JobOrder: PlacementJobOrder{
+						NestedEntity:      NestedEntity{ID: 99, Title: "Job Title ABC"},
+						ClientCorporation: NestedEntity{ID: 5, Name: "GeckoOrg"},
 					},
 					OnboardingStatus: "Completed",
 					ReferralFee:      25,
@@ -243,9 +243,9 @@ func TestPlacementService_Search(t *testing.T) {
 					EmployeeType:   "1",
 					EmploymentType: "Part-time",
 					Fee:            50,
-					JobOrder: NestedEntity{
-						ID:    102,
-						Title: "Job Title CDE",
+					JobOrder: PlacementJobOrder{
+						NestedEntity:      NestedEntity{ID: 102, Title: "Job Title CDE"},
+						ClientCorporation: NestedEntity{ID: 5, Name: "GeckoOrg"},
 					},
 					OnboardingStatus: "Started",
 					ReferralFee:      0,
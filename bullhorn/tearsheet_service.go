@@ -0,0 +1,55 @@
+package bullhorn
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+type TearsheetService interface {
+	Search(context.Context, SearchQuery) (*Tearsheets, error)
+}
+
+type tearsheetService struct {
+	baseURL string
+	client  *Client
+}
+
+type Tearsheets struct {
+	Items []Tearsheet `json:"data"`
+}
+
+// Tearsheet is a hotlist of candidates curated for clients
+type Tearsheet struct {
+	ID int `json:"id"`
+
+	DateAdded EpochMilli `json:"dateAdded"`
+
+	Name           string `json:"name"`
+	Owner          Person `json:"owner"`
+	IsPrivate      bool   `json:"isPrivate"`
+	Candidates     ToMany `json:"candidates"`
+	ClientContacts ToMany `json:"clientContacts"`
+	JobOrders      ToMany `json:"jobOrders"`
+}
+
+func (t *tearsheetService) Search(ctx context.Context, query SearchQuery) (*Tearsheets, error) {
+	q := url.Values{}
+	q.Add("fields", BuildFields(query.Fields))
+	q.Add("where", query.Where)
+	q.Add("start", strconv.Itoa(query.Start))
+	q.Add("count", strconv.Itoa(query.Count))
+	q.Add("orderBy", "-id")
+
+	req, err := t.client.buildGETRequest(t.client.buildURL(t.baseURL, "/query/Tearsheet", q))
+	if err != nil {
+		return nil, err
+	}
+
+	tearsheets := &Tearsheets{}
+	if err := t.client.doRequest(req.WithContext(ctx), tearsheets); err != nil {
+		return nil, err
+	}
+
+	return tearsheets, nil
+}
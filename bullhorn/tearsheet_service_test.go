@@ -0,0 +1,100 @@
+package bullhorn
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTearsheetService_Search(t *testing.T) {
+	t.Run("returns tearsheets", func(t *testing.T) {
+		want := Tearsheets{
+			Items: []Tearsheet{
+				{
+					ID:        12,
+					DateAdded: EpochMilli(1659190221000),
+					Name:      "Java developers",
+					Owner: Person{
+						ID:        3,
+						FirstName: "User",
+						LastName:  "AB",
+					},
+					Candidates: ToMany{Total: 8},
+					ClientContacts: ToMany{
+						Total: 1,
+						Data:  []NestedEntity{{ID: 4, Name: "Contact A"}},
+					},
+					JobOrders: ToMany{
+						Total: 1,
+						Data:  []NestedEntity{{ID: 5, Title: "Java developer"}},
+					},
+				},
+				{
+					ID:        11,
+					DateAdded: EpochMilli(1659090221000),
+					Name:      "Private list",
+					IsPrivate: true,
+				},
+			},
+		}
+
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.Header.Get("BhRestToken"), "tok-456")
+			assert.Equal(t, r.URL.Query().Get("fields"), "id,dateAdded,status")
+			assert.Equal(t, r.URL.Query().Get("where"), "id>0")
+			assert.Equal(t, r.URL.Query().Get("start"), "0")
+			assert.Equal(t, r.URL.Query().Get("count"), "200")
+			assert.Equal(t, r.URL.Query().Get("orderBy"), "-id")
+
+			json.NewEncoder(w).Encode(want)
+		})
+
+		defer server.Close()
+
+		srv := &tearsheetService{client: &Client{client: &http.Client{}, token: "tok-456"}, baseURL: server.URL}
+		query := SearchQuery{
+			Fields: []string{"id", "dateAdded", "status"},
+			Where:  "id>0",
+			Start:  0,
+			Count:  200,
+		}
+
+		got, err := srv.Search(context.Background(), query)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, &want)
+	})
+
+	t.Run("returns error when request fails", func(t *testing.T) {
+		srv := &tearsheetService{client: New("")}
+		_, err := srv.Search(context.Background(), SearchQuery{})
+		assert.ErrorContains(t, err, "unsupported protocol scheme")
+	})
+
+	t.Run("returns error when request building fail", func(t *testing.T) {
+		srv := &tearsheetService{client: &Client{}, baseURL: string([]byte{0x7f})}
+		_, err := srv.Search(context.Background(), SearchQuery{})
+		assert.ErrorContains(t, err, "net/url: invalid control character in URL")
+	})
+
+	t.Run("returns error when non 200 response code", func(t *testing.T) {
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, "error invalid query")
+		})
+		defer server.Close()
+
+		srv := &tearsheetService{client: New(""), baseURL: server.URL}
+
+		_, err := srv.Search(context.Background(), SearchQuery{})
+		want := &Error{
+			StatusCode:  http.StatusBadRequest,
+			RequestPath: "/query/Tearsheet",
+			Message:     "error invalid query",
+		}
+		assert.DeepEqual(t, err, want)
+	})
+}
@@ -13,6 +13,10 @@ type SearchQuery struct {
 	Where  string
 	Start  int
 	Count  int
+
+	// IncludeRaw also decodes each entity into its Raw field
+	// so fields missing from the struct can be looked up
+	IncludeRaw bool
 }
 
 type EpochMilli uint64
@@ -35,11 +39,19 @@ func (e EpochMilli) String() string {
 	return e.Time().Format(time.RFC3339)
 }
 
+// ToMany is a to-many association, Bullhorn only returns the first
+// few associated entities but the total is the count of all of them
+type ToMany struct {
+	Total int            `json:"total"`
+	Data  []NestedEntity `json:"data"`
+}
+
 type Owners struct {
 	Items []Person `json:"data"`
 }
 
 type Person struct {
+	ID        int    `json:"id"`
 	FirstName string `json:"firstName"`
 	LastName  string `json:"lastName"`
 }
@@ -30,6 +30,8 @@ func Setup() *cobra.Command {
 
 	root.AddCommand(VersionCommand())
 	root.AddCommand(PushCommand())
+	root.AddCommand(ExportCommand())
+	root.AddCommand(HistoryCommand())
 
 	return root
 }
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/config"
+	"bullhorn-to-dataset/geckoboard"
+	"bullhorn-to-dataset/printer"
+	"bullhorn-to-dataset/processor"
+	"bullhorn-to-dataset/sink"
+	"context"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func ExportCommand() *cobra.Command {
+	var credsFromEnv bool
+	var format, output string
+	conf := &config.Config{}
+
+	cmd := &cobra.Command{
+		Use:   "export <dataset>",
+		Short: "Query a dataset's data from Bullhorn and write it to stdout or a file",
+		Long: "Query a dataset's data from Bullhorn and write it to stdout or a file as csv, json or jsonl.\n" +
+			"The dataset is any of the datasets push creates such as placements or bullhorn-placements",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			f, err := sink.ParseFormat(format)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			// The custom objects are checked before asking for the credentials
+			if _, err := processor.New(nil, nil); err != nil {
+				log.Fatal(err)
+			}
+
+			if credsFromEnv {
+				conf.LoadFromEnvs()
+				if err := conf.ValidateBullhorn(); err != nil {
+					log.Fatal(err)
+				}
+			} else {
+				askQuestion(conf, &conf.BullhornUsername, "Bullhorn username")
+				askQuestion(conf, &conf.BullhornPassword, "Bullhorn password")
+			}
+
+			// The progress is printed to stderr so it isn't mixed in with the exported data
+			progress := printer.WriterPrinter{W: os.Stderr}
+
+			ctx := context.Background()
+			progress.Printf("Authenticating with Bullhorn...")
+
+			bc := bullhorn.New(conf.BullhornHost)
+			if err := bc.AuthService.Login(ctx, conf.BullhornUsername, conf.BullhornPassword); err != nil {
+				progress.Printf("Failed\n")
+				log.Fatal(bullhorn.RedactedError(err))
+			}
+
+			progress.Printf("Success\nQuerying data from Bullhorn\n")
+
+			p, err := processor.New(bc, nil)
+			if err != nil {
+				log.Fatal(err)
+			}
+			p.PrintWith(progress)
+
+			dataset, data, err := p.Query(ctx, args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := export(output, f, dataset, data); err != nil {
+				log.Fatal(err)
+			}
+
+			progress.Printf("Exported %d %s records\n", len(data), dataset.Name)
+		},
+	}
+
+	cmd.Flags().BoolVar(&credsFromEnv, "creds-from-env", false, "Read Bullhorn credentials from envs instead of user input")
+	cmd.Flags().StringVar(&conf.BullhornHost, "bullhorn-host", "https://universal.bullhornstaffing.com", "Bullhorn universal API host")
+	cmd.Flags().StringVar(&format, "format", "csv", "Format to write the data in, csv, json or jsonl")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write the data to instead of stdout")
+
+	return cmd
+}
+
+// export writes the data to stdout, or the output file when there is one
+func export(output string, f sink.Format, dataset *geckoboard.Dataset, data geckoboard.Data) error {
+	if output == "" {
+		return sink.Encode(os.Stdout, f, dataset, data)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+
+	if err := sink.Encode(file, f, dataset, data); err != nil {
+		file.Close()
+		return err
+	}
+
+	// Closing flushes the file, so its error means the export wasn't written
+	return file.Close()
+}
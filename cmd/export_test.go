@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"bullhorn-to-dataset/sink"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestExport(t *testing.T) {
+	dataset := &geckoboard.Dataset{
+		Name: "bullhorn-placements",
+		Fields: map[string]geckoboard.Field{
+			"id": {Name: "ID", Type: geckoboard.StringType},
+		},
+	}
+	data := geckoboard.Data{{"id": "1"}, {"id": "2"}}
+
+	t.Run("writes the data to the output file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "placements.csv")
+
+		assert.NilError(t, export(output, sink.CSVFormat, dataset, data))
+
+		b, err := os.ReadFile(output)
+		assert.NilError(t, err)
+		assert.Equal(t, string(b), "id\n1\n2\n")
+	})
+
+	t.Run("returns error when the output file can't be created", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "missing", "placements.csv")
+
+		err := export(output, sink.CSVFormat, dataset, data)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bullhorn-to-dataset/history"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func HistoryCommand() *cobra.Command {
+	var stateDir, historyFile string
+	var limit int
+
+	// load returns the runs oldest first from the history file push keeps
+	load := func() []history.Run {
+		path := historyPath(stateDir, historyFile)
+		if path == "" {
+			log.Fatal("the run history is kept in the state dir, pass the --state-dir or --history-file push was run with")
+		}
+
+		store, err := history.NewFileStore(path, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		runs, err := store.Load()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return runs
+	}
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the recent runs of push and the datasets which have been failing",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := history.WriteRuns(os.Stdout, load(), limit); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	show := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show the details of a single run",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				log.Fatalf("invalid run id %q, expected a number from the list of runs", args[0])
+			}
+
+			for _, r := range load() {
+				if r.ID == id {
+					if err := history.WriteRun(os.Stdout, r); err != nil {
+						log.Fatal(err)
+					}
+
+					return
+				}
+			}
+
+			log.Fatal(fmt.Errorf("no run %d in the history, it may be past the retention", id))
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&stateDir, "state-dir", "", "State dir push was run with, which the history is kept in")
+	cmd.PersistentFlags().StringVar(&historyFile, "history-file", "", "History file push was run with, instead of the one in the state dir")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Number of the most recent runs to list, 0 for all of them")
+
+	cmd.AddCommand(show)
+
+	return cmd
+}
+
+// historyPath is the history file when one is given, otherwise
+// history.jsonl in the state dir, empty without either
+func historyPath(stateDir, historyFile string) string {
+	if historyFile != "" {
+		return historyFile
+	}
+
+	if stateDir != "" {
+		return filepath.Join(stateDir, "history.jsonl")
+	}
+
+	return ""
+}
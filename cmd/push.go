@@ -5,70 +5,642 @@ import (
 	"bullhorn-to-dataset/bullhorn"
 	"bullhorn-to-dataset/config"
 	"bullhorn-to-dataset/geckoboard"
+	"bullhorn-to-dataset/history"
+	"bullhorn-to-dataset/lock"
+	"bullhorn-to-dataset/metrics"
+	"bullhorn-to-dataset/notify"
+	"bullhorn-to-dataset/printer"
 	"bullhorn-to-dataset/processor"
+	"bullhorn-to-dataset/schedule"
+	"bullhorn-to-dataset/shutdown"
+	"bullhorn-to-dataset/sink"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 )
 
+const (
+	// webhookSecretEnv is read from an env so the secret isn't left in the command history
+	webhookSecretEnv = "WEBHOOK_SECRET"
+	// syncTokenEnv is the bearer token POST /sync needs, the route is only served when it's set
+	syncTokenEnv = "SYNC_TOKEN"
+	// smtpPasswordEnv is read from an env like the webhook secret
+	smtpPasswordEnv = "SMTP_PASSWORD"
+)
+
+// pushOptions are the flags of the push command
+type pushOptions struct {
+	conf         *config.Config
+	credsFromEnv bool
+
+	singleRun      bool
+	interval       time.Duration
+	cronExpr       string
+	schedules      []string
+	shutdownGrace  time.Duration
+	lockFile       string
+	waitForLock    time.Duration
+	dryRun         bool
+	dryRunDir      string
+	webhook        sink.WebhookOptions
+	webhookHeaders []string
+
+	continueOnBatchError bool
+	fullRefreshInterval  time.Duration
+
+	listen           string
+	healthyIntervals int
+
+	historyFile      string
+	historyRetention time.Duration
+
+	notifyAfter  int
+	notifyRepeat time.Duration
+	chatWebhooks []string
+	smtpOptions  notify.SMTPOptions
+}
+
+// runOnce is whether to run once without a scheduler,
+// which also means syncs can't be triggered
+func (o *pushOptions) runOnce() bool {
+	return o.singleRun || o.dryRun
+}
+
 func PushCommand() *cobra.Command {
-	var credsFromEnv, singleRun bool
-	conf := &config.Config{}
+	o := &pushOptions{conf: &config.Config{}}
 
 	cmd := &cobra.Command{
 		Use:       "push",
 		Short:     "Query Bullhorn data and push data to Geckoboard",
 		ValidArgs: []string{"creds-from-env"},
 		Run: func(cmd *cobra.Command, args []string) {
-			if credsFromEnv {
-				conf.LoadFromEnvs()
-				if err := conf.Validate(); err != nil {
+			p, err := newPusher(o)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			ctx, cancel := shutdown.Notify(context.Background(), o.shutdownGrace, os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			runOnce := o.runOnce()
+
+			var scheduler *schedule.Scheduler
+			if !runOnce {
+				scheduler, err = newScheduler(o.interval, cmd.Flags().Changed("interval"), o.cronExpr, o.schedules)
+				if err != nil {
 					log.Fatal(err)
 				}
-			} else {
-				askQuestion(conf, &conf.BullhornUsername, "Bullhorn username")
-				askQuestion(conf, &conf.BullhornPassword, "Bullhorn password")
-				askQuestion(conf, &conf.GeckoboardAPIKey, "Geckoboard apikey")
-			}
 
-			for {
-				ctx := context.Background()
-				fmt.Printf("Authenticating with Bullhorn...")
+				notifyTrigger(ctx, scheduler)
+			}
 
-				bc := bullhorn.New(conf.BullhornHost)
-				if err := bc.AuthService.Login(ctx, conf.BullhornUsername, conf.BullhornPassword); err != nil {
-					fmt.Printf("Failed\n")
+			if o.listen != "" {
+				p.registry, err = serveMetrics(ctx, o, scheduler)
+				if err != nil {
 					log.Fatal(err)
 				}
+			}
 
-				fmt.Printf("Success\nQuerying data from Bullhorn\n")
+			if runOnce {
+				ran, err := p.run(ctx, nil)
+				if err != nil {
+					log.Fatal(err)
+				}
 
-				gc := geckoboard.New(conf.GeckoboardHost, conf.GeckoboardAPIKey)
-				processor.New(bc, gc).ProcessAll(ctx)
+				if !ran {
+					return
+				}
 
-				if singleRun {
-					fmt.Println("Finished")
+				if shutdown.IsStopping(ctx) {
+					fmt.Println("Shut down before finishing")
 					return
-				} else {
-					fmt.Printf("Sleeping for 15mins")
-					time.Sleep(15 * time.Minute)
 				}
+
+				fmt.Println("Finished")
+				return
 			}
+
+			err = scheduler.Run(ctx, func(ctx context.Context, datasets []string) {
+				if _, err := p.run(ctx, datasets); err != nil {
+					log.Fatal(err)
+				}
+			})
+			if errors.Is(err, shutdown.ErrStopping) || errors.Is(err, context.Canceled) {
+				fmt.Println("Shut down")
+				return
+			}
+
+			log.Fatal(err)
 		},
 	}
 
-	cmd.Flags().BoolVar(&credsFromEnv, "creds-from-env", false, "Read credentials from envs instead of user input")
-	cmd.Flags().BoolVar(&singleRun, "single-run", false, "Run querying data from Bullhorn just once and exit")
-	cmd.Flags().StringVar(&conf.GeckoboardHost, "geckoboard-host", "https://api.geckoboard.com", "Geckoboard host to push data to")
-	cmd.Flags().StringVar(&conf.BullhornHost, "bullhorn-host", "https://universal.bullhornstaffing.com", "Bullhorn universal API host")
+	cmd.Flags().BoolVar(&o.credsFromEnv, "creds-from-env", false, "Read credentials from envs instead of user input")
+	cmd.Flags().BoolVar(&o.singleRun, "single-run", false, "Run querying data from Bullhorn just once and exit")
+	cmd.Flags().StringVar(&o.conf.GeckoboardHost, "geckoboard-host", "https://api.geckoboard.com", "Geckoboard host to push data to")
+	cmd.Flags().StringVar(&o.conf.BullhornHost, "bullhorn-host", "https://universal.bullhornstaffing.com", "Bullhorn universal API host")
+	cmd.Flags().DurationVar(&o.interval, "interval", 15*time.Minute, "How long to wait after pushing before pushing again")
+	cmd.Flags().StringVar(&o.cronExpr, "cron", "", "5 field cron expression of when to push, instead of an interval")
+	cmd.Flags().StringArrayVar(&o.schedules, "schedule", nil, "Schedule of a single dataset as dataset=interval or dataset=cron expression, can be repeated")
+	cmd.Flags().DurationVar(&o.shutdownGrace, "shutdown-grace", 30*time.Second, "How long to let the batch being pushed finish when stopped before aborting it")
+	cmd.Flags().StringVar(&o.listen, "listen", "", "Address to serve /healthz, /readyz and /metrics on, like :9090, along with /sync when SYNC_TOKEN is set")
+	cmd.Flags().IntVar(&o.healthyIntervals, "healthy-intervals", 3, "Longest gaps between scheduled runs since the last successful run after which /healthz reports unhealthy")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "Query Bullhorn and print what would be pushed to Geckoboard without pushing it, runs once")
+	cmd.Flags().StringVar(&o.dryRunDir, "dry-run-dir", "", "Directory to write the payloads a dry run would have sent to")
+	cmd.Flags().StringSliceVar(&o.conf.Sinks, "sink", []string{"geckoboard"}, "Where to write the data, any of geckoboard, csv, jsonl and webhook")
+	cmd.Flags().StringVar(&o.conf.OutputDir, "output-dir", "output", "Directory the csv and jsonl sinks write a file per dataset to")
+	cmd.Flags().StringVar(&o.webhook.URL, "webhook-url", "", "URL the webhook sink posts each dataset's schema and rows to")
+	cmd.Flags().StringArrayVar(&o.webhookHeaders, "webhook-header", nil, "Header to send with each webhook request as \"Name: value\", can be repeated")
+	cmd.Flags().IntVar(&o.webhook.BatchSize, "webhook-batch-size", 500, "Number of rows posted to the webhook in each request")
+	cmd.Flags().IntVar(&o.webhook.Retry.MaxAttempts, "webhook-attempts", 3, "Times to try posting a batch to the webhook before giving up")
+	cmd.Flags().DurationVar(&o.webhook.Retry.Backoff, "webhook-backoff", time.Second, "Wait before retrying a webhook request, doubled after each retry")
+	cmd.Flags().StringVar(&o.lockFile, "lock-file", "", "Lock file held during each run so runs don't overlap, defaults to push.lock in the state dir or the temp dir")
+	cmd.Flags().DurationVar(&o.waitForLock, "wait-for-lock", 0, "How long to wait for another run to finish before skipping this one")
+	cmd.Flags().StringVar(&o.historyFile, "history-file", "", "File to keep a report of each run in, defaults to history.jsonl in the state dir when there is one")
+	cmd.Flags().DurationVar(&o.historyRetention, "history-retention", 30*24*time.Hour, "How long to keep the report of each run for, 0 to keep them forever")
+	cmd.Flags().IntVar(&o.notifyAfter, "notify-after", 3, "Runs a dataset has to fail in a row before alerting about it")
+	cmd.Flags().DurationVar(&o.notifyRepeat, "notify-repeat", 24*time.Hour, "How often to alert again about a dataset which is still failing, 0 to only alert once")
+	cmd.Flags().StringArrayVar(&o.chatWebhooks, "notify-webhook-url", nil, "Slack or Teams incoming webhook url to send alerts to, can be repeated")
+	cmd.Flags().StringVar(&o.smtpOptions.Addr, "smtp-addr", "", "host:port of the SMTP server to email alerts with")
+	cmd.Flags().StringVar(&o.smtpOptions.Username, "smtp-username", "", "Username of the SMTP server, the password is read from SMTP_PASSWORD")
+	cmd.Flags().StringVar(&o.smtpOptions.From, "smtp-from", "", "Address alert emails are sent from")
+	cmd.Flags().StringSliceVar(&o.smtpOptions.To, "smtp-to", nil, "Addresses to email alerts to")
+	cmd.Flags().StringVar(&o.conf.StateDir, "state-dir", "", "Directory to keep upload progress and row hashes in, so a failed push resumes and unchanged rows are skipped")
+	cmd.Flags().DurationVar(&o.fullRefreshInterval, "full-refresh-interval", 24*time.Hour, "How often to push every row again when skipping unchanged rows with a state dir")
+	cmd.Flags().BoolVar(&o.continueOnBatchError, "continue-on-batch-error", false, "Keep pushing the remaining batches of a dataset when one fails")
+	cmd.Flags().StringVar(&o.conf.InvalidRows, "invalid-rows", "fix", "What to do with rows not matching the dataset schema, fix or drop them")
 
 	return cmd
 }
 
+// pusher pushes the datasets on each run, with everything
+// which lasts between the runs set up once at the start
+type pusher struct {
+	conf                 *config.Config
+	dryRun               bool
+	dryRunDir            string
+	continueOnBatchError bool
+	fullRefreshInterval  time.Duration
+	policy               geckoboard.ValidationPolicy
+	lockFile             string
+	waitForLock          time.Duration
+	// retryLogin is set when running on a schedule, so a failed login
+	// skips the run and is tried again on the next rather than exiting
+	retryLogin bool
+
+	// useState is false for a dry run so the next push is unchanged
+	useState      bool
+	otherSinks    []sink.Sink
+	progressStore geckoboard.ProgressStore
+
+	// registry is only set when serving metrics
+	registry *metrics.Registry
+	// monitor is only set when there is somewhere to send alerts, a dry run doesn't send any
+	monitor *notify.Monitor
+	// historyStore is only set when keeping a history of the runs, which a dry run doesn't
+	historyStore *history.FileStore
+}
+
+// newPusher reads the credentials and sets up the sinks,
+// state, alerts and history the runs share
+func newPusher(o *pushOptions) (*pusher, error) {
+	sinkNames, err := sink.ParseNames(o.conf.Sinks)
+	if err != nil {
+		return nil, err
+	}
+
+	// The custom objects are checked before asking for the credentials
+	if _, err := processor.New(nil, nil); err != nil {
+		return nil, err
+	}
+
+	if err := readCredentials(o); err != nil {
+		return nil, err
+	}
+
+	p := &pusher{
+		conf:                 o.conf,
+		dryRun:               o.dryRun,
+		dryRunDir:            o.dryRunDir,
+		continueOnBatchError: o.continueOnBatchError,
+		fullRefreshInterval:  o.fullRefreshInterval,
+		lockFile:             o.lockFile,
+		waitForLock:          o.waitForLock,
+		retryLogin:           !o.runOnce(),
+		useState:             o.conf.StateDir != "" && !o.dryRun,
+	}
+
+	p.otherSinks, err = newOtherSinks(o, sinkNames)
+	if err != nil {
+		return nil, err
+	}
+
+	p.policy, err = geckoboard.ParseValidationPolicy(o.conf.InvalidRows)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.useState {
+		p.progressStore, err = geckoboard.NewFileProgressStore(o.conf.StateDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !o.dryRun {
+		p.monitor, err = newMonitor(o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.lockFile == "" {
+		p.lockFile = defaultLockFile(o.conf.StateDir)
+	}
+
+	if path := historyPath(o.conf.StateDir, o.historyFile); path != "" && !o.dryRun {
+		p.historyStore, err = history.NewFileStore(path, o.historyRetention)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// readCredentials reads them from the envs or asks for them, a dry run
+// doesn't send anything to Geckoboard so doesn't need its apikey
+func readCredentials(o *pushOptions) error {
+	needsGeckoboard := o.conf.UsesGeckoboard() && !o.dryRun
+
+	if !o.credsFromEnv {
+		askQuestion(o.conf, &o.conf.BullhornUsername, "Bullhorn username")
+		askQuestion(o.conf, &o.conf.BullhornPassword, "Bullhorn password")
+		if needsGeckoboard {
+			askQuestion(o.conf, &o.conf.GeckoboardAPIKey, "Geckoboard apikey")
+		}
+
+		return nil
+	}
+
+	o.conf.LoadFromEnvs()
+	if !needsGeckoboard {
+		return o.conf.ValidateBullhorn()
+	}
+
+	return o.conf.Validate()
+}
+
+// newOtherSinks creates the sinks other than Geckoboard, a dry run only
+// says which it isn't writing to
+func newOtherSinks(o *pushOptions, sinkNames []string) ([]sink.Sink, error) {
+	var err error
+	o.webhook.Headers, err = sink.ParseHeaders(o.webhookHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	o.webhook.Secret = os.Getenv(webhookSecretEnv)
+
+	if !o.dryRun {
+		return newSinks(sinkNames, o.conf.OutputDir, o.webhook)
+	}
+
+	for _, n := range sinkNames {
+		if n != sink.GeckoboardName {
+			fmt.Printf("Dry run: not writing to %s\n", n)
+		}
+	}
+
+	return nil, nil
+}
+
+// newMonitor returns nil when there is nowhere to send alerts
+func newMonitor(o *pushOptions) (*notify.Monitor, error) {
+	o.smtpOptions.Password = os.Getenv(smtpPasswordEnv)
+
+	notifiers, err := newNotifiers(o.smtpOptions, o.chatWebhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notifiers) == 0 {
+		return nil, nil
+	}
+
+	monitor := notify.NewMonitor(notifiers, o.notifyAfter, o.notifyRepeat, printer.LogPrinter{})
+	if o.conf.StateDir != "" {
+		return monitor, monitor.KeepStateIn(o.conf.StateDir)
+	}
+
+	if o.singleRun && o.notifyAfter > 1 {
+		// Each single run is a new process which couldn't count the failures in a row
+		return nil, errors.New("alerting with --single-run needs a --state-dir to count the runs failed in a row, or --notify-after 1")
+	}
+
+	return monitor, nil
+}
+
+// serveMetrics serves the health checks and metrics, along with the
+// sync route when there is a scheduler and the sync token is set
+func serveMetrics(ctx context.Context, o *pushOptions, scheduler *schedule.Scheduler) (*metrics.Registry, error) {
+	registry := metrics.NewRegistry()
+
+	// The runs are as far apart as the longest gap of the schedules, such as a day with a daily cron
+	gap := o.interval
+	if scheduler != nil {
+		if g := scheduler.LongestGap(time.Now()); g > 0 {
+			gap = g
+		}
+	}
+
+	mux := registry.Handler(time.Duration(o.healthyIntervals) * gap)
+
+	token := os.Getenv(syncTokenEnv)
+	if scheduler != nil && token != "" {
+		proc, err := processor.New(nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle("/sync", scheduler.SyncHandler(token, proc.DatasetName))
+	}
+
+	addr, err := registry.Serve(ctx, o.listen, mux)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Serving health checks and metrics on %s\n", addr)
+	return registry, nil
+}
+
+// run pushes the datasets, or every dataset without any. ran is
+// false when skipped as another run holds the lock
+func (p *pusher) run(ctx context.Context, datasets []string) (ran bool, err error) {
+	l, err := lock.Acquire(ctx, p.lockFile, p.waitForLock)
+	if err != nil {
+		var held *lock.HeldError
+		if errors.As(err, &held) {
+			fmt.Printf("Skipping run as another run is still going, %s\n", err)
+			return false, nil
+		}
+
+		if shutdown.IsStopping(ctx) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	defer func() {
+		if err := l.Release(); err != nil {
+			fmt.Printf("Releasing the lock failed with error: %s\n", err)
+		}
+	}()
+
+	var report *history.Recorder
+	if p.historyStore != nil {
+		report = history.NewRecorder()
+		defer func() {
+			if _, err := p.historyStore.Append(report.Run()); err != nil {
+				fmt.Printf("Saving the run history failed with error: %s\n", err)
+			}
+		}()
+	}
+
+	// transport records the requests to the service in the metrics and run history
+	transport := func(service string) http.RoundTripper {
+		var t http.RoundTripper
+		if p.registry != nil {
+			t = p.registry.Transport(service, nil)
+		}
+
+		if report != nil {
+			t = report.Transport(service, t)
+		}
+
+		return t
+	}
+
+	fmt.Printf("Authenticating with Bullhorn...")
+
+	bc := bullhorn.New(p.conf.BullhornHost)
+	bc.SetTransport(transport("bullhorn"))
+
+	if err := bc.AuthService.Login(ctx, p.conf.BullhornUsername, p.conf.BullhornPassword); err != nil {
+		fmt.Printf("Failed\n")
+		if report != nil {
+			report.Fail(fmt.Errorf("logging in to bullhorn: %w", err))
+		}
+
+		if shutdown.IsStopping(ctx) {
+			return true, nil
+		}
+
+		if p.monitor != nil {
+			p.monitor.RecordLogin(err)
+		}
+
+		if p.retryLogin {
+			fmt.Printf("Logging in to Bullhorn failed with error: %s, trying again next run\n", bullhorn.RedactedError(err))
+			return true, nil
+		}
+
+		return true, err
+	}
+
+	if p.monitor != nil {
+		p.monitor.RecordLogin(nil)
+	}
+
+	fmt.Printf("Success\nQuerying data from Bullhorn\n")
+
+	sinks, err := p.sinks(transport("geckoboard"))
+	if err != nil {
+		return true, err
+	}
+
+	proc, err := processor.New(bc, sinks)
+	if err != nil {
+		return true, err
+	}
+	if p.registry != nil {
+		proc.RecordWith(p.registry)
+	}
+
+	if p.monitor != nil {
+		proc.RecordWith(p.monitor)
+	}
+
+	if report != nil {
+		proc.RecordWith(report)
+	}
+
+	if p.useState {
+		if err := proc.EnableChangeDetection(p.conf.StateDir, p.fullRefreshInterval); err != nil {
+			return true, err
+		}
+	}
+
+	if datasets == nil {
+		proc.ProcessAll(ctx)
+	} else {
+		proc.Process(ctx, datasets)
+	}
+
+	return true, nil
+}
+
+// sinks returns the sinks of a run, Geckoboard needs
+// a new client each run so it's added to the others
+func (p *pusher) sinks(transport http.RoundTripper) ([]sink.Sink, error) {
+	if !p.conf.UsesGeckoboard() && !p.dryRun {
+		return p.otherSinks, nil
+	}
+
+	gc := geckoboard.New(p.conf.GeckoboardHost, p.conf.GeckoboardAPIKey)
+	gc.ValidationPolicy = p.policy
+	gc.ContinueOnBatchError = p.continueOnBatchError
+	gc.ProgressStore = p.progressStore
+
+	gc.SetTransport(transport)
+
+	if p.dryRun {
+		if err := gc.DryRun(os.Stdout, p.dryRunDir); err != nil {
+			return nil, err
+		}
+	}
+
+	return append([]sink.Sink{sink.NewGeckoboard(gc)}, p.otherSinks...), nil
+}
+
+// newScheduler schedules every dataset on the interval or cron expression,
+// unless it has its own schedule formatted as dataset=schedule.
+// intervalSet is whether the interval was passed rather than the default
+func newScheduler(interval time.Duration, intervalSet bool, cronExpr string, schedules []string) (*schedule.Scheduler, error) {
+	if intervalSet && cronExpr != "" {
+		return nil, errors.New("--interval and --cron can't be used together, pass only one of them")
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid interval %s, must be more than zero", interval)
+	}
+
+	var def schedule.Schedule = schedule.Interval(interval)
+	if cronExpr != "" {
+		c, err := schedule.ParseCron(cronExpr)
+		if err != nil {
+			return nil, err
+		}
+
+		def = c
+	}
+
+	// The processors are only used for their dataset names
+	p, err := processor.New(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	overrides := map[string]schedule.Schedule{}
+
+	for _, s := range schedules {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid schedule %q, expected dataset=schedule like contacts=1h", s)
+		}
+
+		name, err := p.DatasetName(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, err
+		}
+
+		overrides[name], err = schedule.Parse(parts[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schedule.New(p.Datasets(), def, overrides, schedule.RealClock{}, printer.LogPrinter{}), nil
+}
+
+// newSinks creates the sinks other than Geckoboard, which
+// is created on each run with a new client
+func newSinks(names []string, dir string, webhook sink.WebhookOptions) ([]sink.Sink, error) {
+	sinks := []sink.Sink{}
+
+	for _, n := range names {
+		switch n {
+		case sink.CSVName:
+			s, err := sink.NewCSV(dir)
+			if err != nil {
+				return nil, err
+			}
+
+			sinks = append(sinks, s)
+		case sink.JSONLinesName:
+			s, err := sink.NewJSONLines(dir)
+			if err != nil {
+				return nil, err
+			}
+
+			sinks = append(sinks, s)
+		case sink.WebhookName:
+			s, err := sink.NewWebhook(webhook)
+			if err != nil {
+				return nil, err
+			}
+
+			sinks = append(sinks, s)
+		}
+	}
+
+	return sinks, nil
+}
+
+// defaultLockFile keeps the lock with the rest of the state, falling
+// back to the temp dir so runs without one on the same machine don't overlap
+func defaultLockFile(stateDir string) string {
+	if stateDir != "" {
+		return filepath.Join(stateDir, "push.lock")
+	}
+
+	return filepath.Join(os.TempDir(), "bullhorn-to-dataset-push.lock")
+}
+
+// newNotifiers creates a notifier emailing alerts when an SMTP
+// server is set and one for each chat webhook
+func newNotifiers(smtpOptions notify.SMTPOptions, chatWebhooks []string) ([]notify.Notifier, error) {
+	notifiers := []notify.Notifier{}
+
+	if smtpOptions.Addr != "" {
+		n, err := notify.NewSMTP(smtpOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		notifiers = append(notifiers, n)
+	}
+
+	for _, u := range chatWebhooks {
+		n, err := notify.NewChat(u)
+		if err != nil {
+			return nil, err
+		}
+
+		notifiers = append(notifiers, n)
+	}
+
+	return notifiers, nil
+}
+
 func askQuestion(conf *config.Config, attrRef *string, question string) {
 	val, err := conf.ReadValueFromInput(bufio.NewReader(os.Stdin), question)
 	if err != nil {
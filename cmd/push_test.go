@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bullhorn-to-dataset/config"
+	"bullhorn-to-dataset/notify"
+	"bullhorn-to-dataset/printer"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPusher_Run(t *testing.T) {
+	t.Run("alerts once when logging in keeps failing on a schedule", func(t *testing.T) {
+		logins := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logins++
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		notifier := &mockNotifier{}
+		p := &pusher{
+			conf:       &config.Config{BullhornHost: server.URL},
+			lockFile:   filepath.Join(t.TempDir(), "push.lock"),
+			retryLogin: true,
+			monitor:    notify.NewMonitor([]notify.Notifier{notifier}, 3, 24*time.Hour, printer.LogPrinter{}),
+		}
+
+		for i := 0; i < 5; i++ {
+			ran, err := p.run(context.Background(), nil)
+			assert.NilError(t, err)
+			assert.Assert(t, ran)
+		}
+
+		assert.Equal(t, logins, 5)
+		assert.Equal(t, len(notifier.sent), 1)
+		assert.Equal(t, notifier.sent[0].Subject, "bullhorn-to-dataset: 1 dataset failing")
+	})
+
+	t.Run("returns the error when logging in fails running once", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		p := &pusher{
+			conf:     &config.Config{BullhornHost: server.URL},
+			lockFile: filepath.Join(t.TempDir(), "push.lock"),
+		}
+
+		ran, err := p.run(context.Background(), nil)
+		assert.ErrorContains(t, err, "401")
+		assert.Assert(t, ran)
+	})
+}
+
+type mockNotifier struct {
+	sent []notify.Message
+}
+
+func (m *mockNotifier) String() string {
+	return "mock"
+}
+
+func (m *mockNotifier) Send(_ context.Context, msg notify.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
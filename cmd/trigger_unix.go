@@ -0,0 +1,32 @@
+//go:build !windows
+// +build !windows
+
+package cmd
+
+import (
+	"bullhorn-to-dataset/schedule"
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyTrigger triggers a sync of every dataset on each SIGUSR1
+// until the context is done
+func notifyTrigger(ctx context.Context, scheduler *schedule.Scheduler) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				scheduler.Trigger(nil)
+			}
+		}
+	}()
+}
@@ -0,0 +1,13 @@
+//go:build windows
+// +build windows
+
+package cmd
+
+import (
+	"bullhorn-to-dataset/schedule"
+	"context"
+)
+
+// notifyTrigger does nothing as there is no SIGUSR1 on windows,
+// syncs can still be triggered with POST /sync
+func notifyTrigger(context.Context, *schedule.Scheduler) {}
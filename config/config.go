@@ -19,6 +19,17 @@ type Config struct {
 	// GeckoboardAPIKey to push
 	GeckoboardAPIKey string
 	GeckoboardHost   string
+
+	// InvalidRows is the policy for rows not matching the dataset schema
+	InvalidRows string
+
+	// Sinks are the names of where data is written to, Geckoboard when empty
+	Sinks []string
+	// OutputDir is where the file sinks write to
+	OutputDir string
+
+	// StateDir is where upload progress and row hashes are kept between runs
+	StateDir string
 }
 
 // FromEnv reads secret config values from environment variables
@@ -29,9 +40,10 @@ func (c *Config) LoadFromEnvs() {
 }
 
 // ReadValueFromInput reads secrets from stdin instead of using
-// command args to prevent secrets being available in command history
+// command args to prevent secrets being available in command history.
+// The question is asked on stderr so it isn't mixed in with data on stdout
 func (c *Config) ReadValueFromInput(reader *bufio.Reader, question string) (string, error) {
-	fmt.Printf("Enter your %s: ", question)
+	fmt.Fprintf(os.Stderr, "Enter your %s: ", question)
 	v, err := reader.ReadString('\n')
 	if err != nil {
 		return "", err
@@ -46,6 +58,28 @@ func (c *Config) ReadValueFromInput(reader *bufio.Reader, question string) (stri
 
 // Validate returns an error if any of the config values are missing
 func (c *Config) Validate() error {
+	if err := c.ValidateBullhorn(); err != nil {
+		return err
+	}
+
+	if !c.UsesGeckoboard() {
+		return nil
+	}
+
+	if c.GeckoboardAPIKey == "" {
+		return fmt.Errorf(errMissingValue, "geckoboard apikey")
+	}
+
+	if c.GeckoboardHost == "" {
+		return fmt.Errorf(errMissingValue, "geckoboard host")
+	}
+
+	return nil
+}
+
+// ValidateBullhorn returns an error if any of the Bullhorn config values
+// are missing, for commands which don't push data to Geckoboard
+func (c *Config) ValidateBullhorn() error {
 	if c.BullhornUsername == "" {
 		return fmt.Errorf(errMissingValue, "bullhorn username")
 	}
@@ -58,13 +92,21 @@ func (c *Config) Validate() error {
 		return fmt.Errorf(errMissingValue, "bullhorn host")
 	}
 
-	if c.GeckoboardAPIKey == "" {
-		return fmt.Errorf(errMissingValue, "geckoboard apikey")
+	return nil
+}
+
+// UsesGeckoboard returns true when data is pushed to Geckoboard,
+// the Geckoboard config is only needed when it is
+func (c *Config) UsesGeckoboard() bool {
+	if len(c.Sinks) == 0 {
+		return true
 	}
 
-	if c.GeckoboardHost == "" {
-		return fmt.Errorf(errMissingValue, "geckoboard host")
+	for _, s := range c.Sinks {
+		if strings.EqualFold(s, "geckoboard") {
+			return true
+		}
 	}
 
-	return nil
+	return false
 }
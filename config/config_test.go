@@ -101,6 +101,24 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			out: "geckoboard host",
 		},
+		{
+			in: &Config{
+				BullhornUsername: "test",
+				BullhornPassword: "pa55",
+				BullhornHost:     "example.com",
+				Sinks:            []string{"csv", "jsonl"},
+			},
+			out: "",
+		},
+		{
+			in: &Config{
+				BullhornUsername: "test",
+				BullhornPassword: "pa55",
+				BullhornHost:     "example.com",
+				Sinks:            []string{"csv", "Geckoboard"},
+			},
+			out: "geckoboard apikey",
+		},
 	}
 
 	for _, spec := range specs {
@@ -116,3 +134,15 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ValidateBullhorn(t *testing.T) {
+	c := &Config{
+		BullhornUsername: "test",
+		BullhornPassword: "pa55",
+		BullhornHost:     "example.com",
+	}
+	assert.NilError(t, c.ValidateBullhorn())
+
+	c.BullhornHost = ""
+	assert.Error(t, c.ValidateBullhorn(), fmt.Sprintf(errMissingValue, "bullhorn host"))
+}
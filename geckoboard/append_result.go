@@ -0,0 +1,75 @@
+package geckoboard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AppendResult is the outcome of pushing data in batches
+type AppendResult struct {
+	// Batches is the number of batches attempted
+	Batches int
+	// Sent is the number of batches Geckoboard accepted
+	Sent int
+	// Skipped is the number of batches a previous run already sent
+	Skipped int
+	// RecordsSent is the number of rows in the batches sent
+	RecordsSent int
+
+	Failed   []BatchError
+	Rejected []RejectedRow
+}
+
+// Err returns an AppendError when any of the batches failed
+func (r AppendResult) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+
+	return &AppendError{Batches: r.Batches, Failed: r.Failed}
+}
+
+// BatchError is a batch Geckoboard didn't accept. Start and End are
+// the range of rows in the batch, after any invalid rows were dropped
+type BatchError struct {
+	Batch int
+	Start int
+	End   int
+	Err   error
+}
+
+func (e BatchError) Error() string {
+	return fmt.Sprintf("batch %d (rows %d-%d): %s", e.Batch+1, e.Start+1, e.End, e.Err)
+}
+
+func (e BatchError) Unwrap() error {
+	return e.Err
+}
+
+// AppendError is returned by AppendData when batches failed to send
+type AppendError struct {
+	Batches int
+	Failed  []BatchError
+}
+
+func (e *AppendError) Error() string {
+	if e.Batches == 1 && len(e.Failed) == 1 {
+		return e.Failed[0].Err.Error()
+	}
+
+	errs := []string{}
+	for _, f := range e.Failed {
+		errs = append(errs, f.Error())
+	}
+
+	return fmt.Sprintf("%d of %d batches failed: %s", len(e.Failed), e.Batches, strings.Join(errs, "; "))
+}
+
+// Unwrap returns the error of the first failed batch
+func (e *AppendError) Unwrap() error {
+	if len(e.Failed) == 0 {
+		return nil
+	}
+
+	return e.Failed[0].Err
+}
@@ -0,0 +1,38 @@
+package geckoboard
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAppendResult_Err(t *testing.T) {
+	t.Run("returns nil when no batches failed", func(t *testing.T) {
+		assert.NilError(t, AppendResult{Batches: 2, Sent: 2}.Err())
+	})
+
+	t.Run("returns the batch error when there was only one batch", func(t *testing.T) {
+		err := AppendResult{
+			Batches: 1,
+			Failed:  []BatchError{{Batch: 0, Start: 0, End: 10, Err: errors.New("push failed")}},
+		}.Err()
+
+		assert.Error(t, err, "push failed")
+	})
+
+	t.Run("returns each failed batch with its rows", func(t *testing.T) {
+		first := errors.New("first failed")
+		err := AppendResult{
+			Batches: 3,
+			Sent:    1,
+			Failed: []BatchError{
+				{Batch: 0, Start: 0, End: 500, Err: first},
+				{Batch: 2, Start: 1000, End: 1200, Err: errors.New("third failed")},
+			},
+		}.Err()
+
+		assert.Error(t, err, "2 of 3 batches failed: batch 1 (rows 1-500): first failed; batch 3 (rows 1001-1200): third failed")
+		assert.Assert(t, errors.Is(err, first))
+	})
+}
@@ -14,12 +14,27 @@ var (
 		"https://geckoboard.statuspage.io")
 )
 
+// maxRecordsPerRequest is the most rows Geckoboard accepts in a single request
+const maxRecordsPerRequest = 500
+
 type Client struct {
 	client  *http.Client
 	baseURL string
 	apiKey  string
 
 	DatasetService DatasetService
+
+	// ValidationPolicy is what happens to rows not matching
+	// the dataset schema, invalid rows are fixed by default
+	ValidationPolicy ValidationPolicy
+
+	// ContinueOnBatchError keeps pushing the remaining
+	// batches of data after one of them has failed
+	ContinueOnBatchError bool
+
+	// ProgressStore when set records the batches pushed
+	// so a failed push can resume where it stopped
+	ProgressStore ProgressStore
 }
 
 func New(baseURL, apikey string) *Client {
@@ -27,17 +42,25 @@ func New(baseURL, apikey string) *Client {
 		client:  &http.Client{Timeout: 30 * time.Second},
 		baseURL: baseURL,
 		apiKey:  apikey,
+
+		ValidationPolicy: FixInvalidRows,
 	}
 
 	c.DatasetService = &datasetService{
 		client:           c,
-		maxRecordsPerReq: 500,
+		maxRecordsPerReq: maxRecordsPerRequest,
 		jsonMarshalFn:    json.Marshal,
 	}
 
 	return c
 }
 
+// SetTransport sends the client's requests through the
+// transport, such as one counting them for metrics
+func (c *Client) SetTransport(t http.RoundTripper) {
+	c.client.Transport = t
+}
+
 func (c *Client) buildRequest(method, path string, body io.Reader) (*http.Request, error) {
 	r, err := http.NewRequest(method, c.baseURL+path, body)
 	if err != nil {
@@ -73,15 +96,15 @@ func (c *Client) checkResponse(resp *http.Response) error {
 		return nil
 	}
 
-	if resp.StatusCode >= http.StatusInternalServerError {
-		return errUnexpectedResponse
-	}
-
-	gerr := &Error{StatusCode: resp.StatusCode}
-
-	if err := json.NewDecoder(resp.Body).Decode(&gerr); err != nil {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
 		return err
 	}
 
+	// A body which isn't JSON, such as a proxy's error page, is still kept in
+	// the error, server errors also match errUnexpectedResponse
+	gerr := &Error{StatusCode: resp.StatusCode, Body: string(b)}
+	json.Unmarshal(b, gerr)
+
 	return gerr
 }
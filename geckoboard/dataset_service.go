@@ -1,6 +1,7 @@
 package geckoboard
 
 import (
+	"bullhorn-to-dataset/shutdown"
 	"bytes"
 	"context"
 	"fmt"
@@ -9,7 +10,7 @@ import (
 
 type DatasetService interface {
 	FindOrCreate(context.Context, *Dataset) error
-	AppendData(context.Context, *Dataset, Data) error
+	AppendData(context.Context, *Dataset, Data) (AppendResult, error)
 }
 
 type datasetService struct {
@@ -26,8 +27,14 @@ const (
 	DatetimeType FieldType = "datetime"
 	StringType   FieldType = "string"
 	PercentType  FieldType = "percentage"
+	MoneyType    FieldType = "money"
+	DateType     FieldType = "date"
+	DurationType FieldType = "duration"
 )
 
+// MaxStringLength is the most characters Geckoboard accepts for a string field
+const MaxStringLength = 255
+
 type Dataset struct {
 	Name     string           `json:"id"`
 	Fields   map[string]Field `json:"fields"`
@@ -38,6 +45,12 @@ type Field struct {
 	Type     FieldType `json:"type"`
 	Name     string    `json:"name"`
 	Optional bool      `json:"optional"`
+
+	// CurrencyCode is required for money fields, an ISO 4217 code like USD
+	CurrencyCode string `json:"currency_code,omitempty"`
+
+	// TimeUnit is required for duration fields
+	TimeUnit TimeUnit `json:"time_unit,omitempty"`
 }
 
 type DataRow map[string]interface{}
@@ -57,6 +70,10 @@ func (d *datasetService) buildDatasetPath(dataset *Dataset, isData bool) string
 }
 
 func (d *datasetService) FindOrCreate(ctx context.Context, dataset *Dataset) error {
+	if err := dataset.Validate(); err != nil {
+		return err
+	}
+
 	b, err := d.jsonMarshalFn(dataset)
 	if err != nil {
 		return err
@@ -71,29 +88,114 @@ func (d *datasetService) FindOrCreate(ctx context.Context, dataset *Dataset) err
 	return d.client.doRequest(req.WithContext(ctx))
 }
 
-func (d *datasetService) AppendData(ctx context.Context, dataset *Dataset, data Data) error {
-	grps := len(data) / d.maxRecordsPerReq
-	var payload DataPayload
+// AppendData validates the rows against the dataset fields before pushing
+// them in batches. It stops at the first batch Geckoboard doesn't accept
+// unless the client is set to continue past failed batches, either way
+// the result says which batches were sent and which failed. When the client
+// has a progress store, batches sent by a previous run of the same data
+// are skipped so a failed push resumes from the batch that failed
+func (d *datasetService) AppendData(ctx context.Context, dataset *Dataset, data Data) (AppendResult, error) {
+	data, rejected := validateData(dataset, data, d.client.ValidationPolicy)
+	result := AppendResult{Rejected: rejected}
+
+	progress, err := d.loadProgress(dataset, data)
+	if err != nil {
+		return result, err
+	}
 
-	for i := 0; i <= grps; i++ {
-		batch := d.maxRecordsPerReq * i
+	for start := 0; start < len(data); start += d.maxRecordsPerReq {
+		end := start + d.maxRecordsPerReq
+		if end > len(data) {
+			end = len(data)
+		}
+
+		result.Batches++
+		if result.Batches <= progress.BatchesSent {
+			result.Skipped++
+			continue
+		}
 
-		if i == grps {
-			if batch+1 <= len(data) {
-				payload := DataPayload{Data: data[batch:]}
-				if err := d.sendData(ctx, dataset, payload); err != nil {
-					return err
-				}
+		// Stopping between batches leaves the progress of the batches
+		// sent, so the next run carries on from this batch
+		if shutdown.IsStopping(ctx) {
+			return result, fmt.Errorf("stopped before batch %d: %w", result.Batches, shutdown.ErrStopping)
+		}
+
+		if err := d.sendData(ctx, dataset, DataPayload{Data: data[start:end]}); err != nil {
+			result.Failed = append(result.Failed, BatchError{
+				Batch: result.Batches - 1,
+				Start: start,
+				End:   end,
+				Err:   err,
+			})
+
+			if !d.client.ContinueOnBatchError {
+				break
 			}
-		} else {
-			payload = DataPayload{Data: data[batch : d.maxRecordsPerReq*(i+1)]}
-			if err := d.sendData(ctx, dataset, payload); err != nil {
-				return err
+
+			continue
+		}
+
+		result.Sent++
+		result.RecordsSent += end - start
+
+		// Progress only moves on while every batch has been sent, once one
+		// fails the next run has to resume from that batch
+		if len(result.Failed) == 0 {
+			if err := d.saveProgress(dataset, progress.Fingerprint, result.Batches); err != nil {
+				return result, err
 			}
 		}
 	}
 
-	return nil
+	if len(result.Failed) == 0 {
+		if err := d.clearProgress(dataset); err != nil {
+			return result, err
+		}
+	}
+
+	return result, result.Err()
+}
+
+// loadProgress returns the progress of the last run when it
+// was pushing the same data, otherwise nothing has been sent
+func (d *datasetService) loadProgress(dataset *Dataset, data Data) (Progress, error) {
+	store := d.client.ProgressStore
+	if store == nil {
+		return Progress{}, nil
+	}
+
+	fp, err := fingerprint(data, d.maxRecordsPerReq)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	last, err := store.Load(dataset.Name)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	if last == nil || last.Fingerprint != fp {
+		return Progress{Fingerprint: fp}, nil
+	}
+
+	return *last, nil
+}
+
+func (d *datasetService) saveProgress(dataset *Dataset, fp string, batchesSent int) error {
+	if d.client.ProgressStore == nil {
+		return nil
+	}
+
+	return d.client.ProgressStore.Save(dataset.Name, Progress{Fingerprint: fp, BatchesSent: batchesSent})
+}
+
+func (d *datasetService) clearProgress(dataset *Dataset) error {
+	if d.client.ProgressStore == nil {
+		return nil
+	}
+
+	return d.client.ProgressStore.Clear(dataset.Name)
 }
 
 func (d *datasetService) sendData(ctx context.Context, dataset *Dataset, payload DataPayload) error {
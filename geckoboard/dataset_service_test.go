@@ -1,6 +1,7 @@
 package geckoboard
 
 import (
+	"bullhorn-to-dataset/shutdown"
 	"context"
 	"encoding/json"
 	"errors"
@@ -65,6 +66,21 @@ func TestDatasetService_FindOrCreate(t *testing.T) {
 		assert.ErrorContains(t, err, "marshal error")
 	})
 
+	t.Run("returns error without sending when a field is invalid", func(t *testing.T) {
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("request shouldn't have been sent")
+		})
+		defer server.Close()
+
+		err := newService(server.URL).FindOrCreate(context.Background(), &Dataset{
+			Name: "bullhorn-test",
+			Fields: map[string]Field{
+				"salary": {Name: "Salary", Type: MoneyType},
+			},
+		})
+		assert.Error(t, err, `dataset bullhorn-test: money field "Salary" requires a three letter currency code`)
+	})
+
 	t.Run("returns error with invalid url", func(t *testing.T) {
 		ds := newService(string([]byte{0x7f}))
 		err := ds.FindOrCreate(context.Background(), &Dataset{})
@@ -83,7 +99,26 @@ func TestDatasetService_FindOrCreate(t *testing.T) {
 		defer server.Close()
 
 		err := newService(server.URL).FindOrCreate(context.Background(), &Dataset{})
-		assert.Error(t, err, errUnexpectedResponse.Error())
+		assert.DeepEqual(t, err, &Error{StatusCode: http.StatusInternalServerError})
+		assert.ErrorIs(t, err, errUnexpectedResponse)
+		assert.ErrorContains(t, err, "with response code 500")
+	})
+
+	t.Run("returns geckoboard error with the body when response 503", func(t *testing.T) {
+		body := "upstream connect error"
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, body)
+		})
+		defer server.Close()
+
+		err := newService(server.URL).FindOrCreate(context.Background(), &Dataset{})
+		assert.DeepEqual(t, err, &Error{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       body,
+		})
+		assert.ErrorIs(t, err, errUnexpectedResponse)
+		assert.ErrorContains(t, err, `"upstream connect error": with response code 503`)
 	})
 
 	t.Run("returns geckoboard error when response 400", func(t *testing.T) {
@@ -99,18 +134,44 @@ func TestDatasetService_FindOrCreate(t *testing.T) {
 			Detail: Detail{
 				Message: "invalid field type",
 			},
+			Body: `{"error":{"message": "invalid field type"}}`,
 		})
 	})
 
-	t.Run("returns err when it fail to parse error json", func(t *testing.T) {
+	t.Run("returns the full geckoboard error payload", func(t *testing.T) {
+		body := `{"error":{"message":"invalid data","type":"ValidationError","details":{"fields":{"fee":"must be a number"}}}}`
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			io.WriteString(w, body)
+		})
+		defer server.Close()
+
+		err := newService(server.URL).FindOrCreate(context.Background(), &Dataset{})
+		assert.DeepEqual(t, err, &Error{
+			StatusCode: http.StatusUnprocessableEntity,
+			Detail: Detail{
+				Message: "invalid data",
+				Type:    "ValidationError",
+				Details: json.RawMessage(`{"fields":{"fee":"must be a number"}}`),
+			},
+			Body: body,
+		})
+	})
+
+	t.Run("returns geckoboard error with the body when it isn't json", func(t *testing.T) {
+		body := `<html><body>Bad gateway</body></html>`
 		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusBadRequest)
-			io.WriteString(w, `{invalid json}`)
+			io.WriteString(w, body)
 		})
 		defer server.Close()
 
 		err := newService(server.URL).FindOrCreate(context.Background(), &Dataset{})
-		assert.ErrorType(t, err, &json.SyntaxError{})
+		assert.DeepEqual(t, err, &Error{
+			StatusCode: http.StatusBadRequest,
+			Body:       body,
+		})
+		assert.ErrorContains(t, err, `"<html><body>Bad gateway</body></html>": with response code 400`)
 	})
 }
 
@@ -144,9 +205,10 @@ func TestDatasetService_AppendData(t *testing.T) {
 		ds := newService(server.URL)
 		ds.maxRecordsPerReq = 500
 
-		err := ds.AppendData(context.Background(), &Dataset{Name: "test-dataset"}, wantData)
+		result, err := ds.AppendData(context.Background(), &Dataset{Name: "test-dataset"}, wantData)
 		assert.NilError(t, err)
 		assert.Equal(t, requests, 1)
+		assert.DeepEqual(t, result, AppendResult{Batches: 1, Sent: 1, RecordsSent: 1})
 	})
 
 	t.Run("makes a multiple data requests", func(t *testing.T) {
@@ -186,9 +248,231 @@ func TestDatasetService_AppendData(t *testing.T) {
 		ds.maxRecordsPerReq = 2
 
 		ctx := context.Background()
-		err := ds.AppendData(ctx, &Dataset{Name: "test-dataset"}, wantData)
+		result, err := ds.AppendData(ctx, &Dataset{Name: "test-dataset"}, wantData)
 		assert.NilError(t, err)
 		assert.Equal(t, requests, 3)
+		assert.DeepEqual(t, result, AppendResult{Batches: 3, Sent: 3, RecordsSent: 5})
+	})
+
+	t.Run("pushes the valid rows and returns the rejected ones", func(t *testing.T) {
+		var requests int
+
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			requests += 1
+
+			got := &DataPayload{}
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.DeepEqual(t, got, &DataPayload{Data: Data{{"id": "1", "title": "title one"}}})
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		ds := newService(server.URL)
+		ds.client.ValidationPolicy = DropInvalidRows
+
+		dataset := &Dataset{
+			Name: "test-dataset",
+			Fields: map[string]Field{
+				"id":    {Name: "ID", Type: StringType},
+				"title": {Name: "Title", Type: StringType, Optional: true},
+			},
+			UniqueBy: []string{"id"},
+		}
+
+		result, err := ds.AppendData(context.Background(), dataset, Data{
+			{"id": "1", "title": "title one"},
+			{"id": "2", "title": 2},
+		})
+
+		assert.NilError(t, err)
+		assert.DeepEqual(t, result, AppendResult{
+			Batches:     1,
+			Sent:        1,
+			RecordsSent: 1,
+			Rejected: []RejectedRow{
+				{ID: "2", Reasons: []string{`field "title" must be a string`}},
+			},
+		})
+		assert.Equal(t, requests, 1)
+	})
+
+	t.Run("stops at the first failed batch", func(t *testing.T) {
+		var requests int
+
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			requests += 1
+			if requests == 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"error":{"message":"invalid data"}}`)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		ds := newService(server.URL)
+		ds.maxRecordsPerReq = 2
+
+		data := Data{{"id": "1"}, {"id": "2"}, {"id": "3"}, {"id": "4"}, {"id": "5"}}
+		result, err := ds.AppendData(context.Background(), &Dataset{Name: "test-dataset"}, data)
+
+		assert.Equal(t, requests, 2)
+		assert.Equal(t, result.Batches, 2)
+		assert.Equal(t, result.Sent, 1)
+		assert.Equal(t, result.RecordsSent, 2)
+		assert.Equal(t, len(result.Failed), 1)
+		assert.Equal(t, result.Failed[0].Batch, 1)
+		assert.Equal(t, result.Failed[0].Start, 2)
+		assert.Equal(t, result.Failed[0].End, 4)
+		assert.Error(t, err, `1 of 2 batches failed: batch 2 (rows 3-4): There was an error sending the data to Geckoboard's API: "invalid data": with response code 400`)
+
+		var gerr *Error
+		assert.Assert(t, errors.As(err, &gerr))
+		assert.Equal(t, gerr.Message, "invalid data")
+	})
+
+	t.Run("continues past failed batches when enabled", func(t *testing.T) {
+		var requests int
+
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			requests += 1
+			if requests != 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"error":{"message":"invalid data"}}`)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		ds := newService(server.URL)
+		ds.maxRecordsPerReq = 2
+		ds.client.ContinueOnBatchError = true
+
+		data := Data{{"id": "1"}, {"id": "2"}, {"id": "3"}, {"id": "4"}, {"id": "5"}}
+		result, err := ds.AppendData(context.Background(), &Dataset{Name: "test-dataset"}, data)
+
+		assert.Equal(t, requests, 3)
+		assert.Equal(t, result.Batches, 3)
+		assert.Equal(t, result.Sent, 1)
+		assert.Equal(t, result.RecordsSent, 2)
+		assert.Equal(t, len(result.Failed), 2)
+		assert.Equal(t, result.Failed[0].Start, 0)
+		assert.Equal(t, result.Failed[0].End, 2)
+		assert.Equal(t, result.Failed[1].Start, 4)
+		assert.Equal(t, result.Failed[1].End, 5)
+		assert.ErrorContains(t, err, "2 of 3 batches failed: batch 1 (rows 1-2): ")
+		assert.ErrorContains(t, err, "; batch 3 (rows 5-5): ")
+	})
+
+	t.Run("resumes from the failed batch when the data hasn't changed", func(t *testing.T) {
+		store, err := NewFileProgressStore(t.TempDir())
+		assert.NilError(t, err)
+
+		data := Data{{"id": "1"}, {"id": "2"}, {"id": "3"}, {"id": "4"}, {"id": "5"}}
+		dataset := &Dataset{Name: "test-dataset"}
+
+		sent := []string{}
+		failBatch := 2
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			got := &DataPayload{}
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(sent) == failBatch-1 {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"error":{"message":"invalid data"}}`)
+				return
+			}
+
+			sent = append(sent, got.Data[0]["id"].(string))
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		ds := newService(server.URL)
+		ds.maxRecordsPerReq = 2
+		ds.client.ProgressStore = store
+
+		result, err := ds.AppendData(context.Background(), dataset, data)
+		assert.ErrorContains(t, err, "invalid data")
+		assert.Equal(t, result.Sent, 1)
+		assert.DeepEqual(t, sent, []string{"1"})
+
+		progress, err := store.Load("test-dataset")
+		assert.NilError(t, err)
+		assert.Equal(t, progress.BatchesSent, 1)
+
+		failBatch = 0
+		result, err = ds.AppendData(context.Background(), dataset, data)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, result, AppendResult{Batches: 3, Sent: 2, Skipped: 1, RecordsSent: 3})
+		assert.DeepEqual(t, sent, []string{"1", "3", "5"})
+
+		progress, err = store.Load("test-dataset")
+		assert.NilError(t, err)
+		assert.Assert(t, progress == nil)
+	})
+
+	t.Run("stops between batches when shutting down keeping the progress", func(t *testing.T) {
+		store, err := NewFileProgressStore(t.TempDir())
+		assert.NilError(t, err)
+
+		ctx, stop := shutdown.WithStop(context.Background())
+
+		var requests int
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			requests += 1
+
+			// The batch being sent when the stop comes is still finished
+			stop()
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		ds := newService(server.URL)
+		ds.maxRecordsPerReq = 2
+		ds.client.ProgressStore = store
+
+		data := Data{{"id": "1"}, {"id": "2"}, {"id": "3"}}
+		result, err := ds.AppendData(ctx, &Dataset{Name: "test-dataset"}, data)
+		assert.ErrorIs(t, err, shutdown.ErrStopping)
+		assert.Error(t, err, "stopped before batch 2: shutting down")
+		assert.Equal(t, result.Sent, 1)
+		assert.Equal(t, requests, 1)
+
+		progress, err := store.Load("test-dataset")
+		assert.NilError(t, err)
+		assert.Equal(t, progress.BatchesSent, 1)
+	})
+
+	t.Run("starts over when the data has changed", func(t *testing.T) {
+		store, err := NewFileProgressStore(t.TempDir())
+		assert.NilError(t, err)
+		assert.NilError(t, store.Save("test-dataset", Progress{Fingerprint: "old", BatchesSent: 2}))
+
+		var requests int
+		server := buildMockServer(func(w http.ResponseWriter, r *http.Request) {
+			requests += 1
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		ds := newService(server.URL)
+		ds.maxRecordsPerReq = 2
+		ds.client.ProgressStore = store
+
+		data := Data{{"id": "1"}, {"id": "2"}, {"id": "3"}}
+		result, err := ds.AppendData(context.Background(), &Dataset{Name: "test-dataset"}, data)
+		assert.NilError(t, err)
+		assert.Equal(t, result.Skipped, 0)
+		assert.Equal(t, requests, 2)
 	})
 
 	t.Run("returns error when request body marshal fails", func(t *testing.T) {
@@ -202,7 +486,7 @@ func TestDatasetService_AppendData(t *testing.T) {
 		ds.maxRecordsPerReq = 2
 
 		ctx := context.Background()
-		err := ds.AppendData(ctx, &Dataset{Name: "test-dataset"}, Data{{}})
+		_, err := ds.AppendData(ctx, &Dataset{Name: "test-dataset"}, Data{{}})
 		assert.ErrorContains(t, err, "marshal error")
 	})
 
@@ -214,7 +498,7 @@ func TestDatasetService_AppendData(t *testing.T) {
 		ds := newService(string([]byte{0x7f}))
 		ds.maxRecordsPerReq = 1
 
-		err := ds.AppendData(ctx, &Dataset{Name: "test-dataset"}, Data{{}, {}})
+		_, err := ds.AppendData(ctx, &Dataset{Name: "test-dataset"}, Data{{}, {}})
 		assert.ErrorContains(t, err, "invalid control character in URL")
 	})
 }
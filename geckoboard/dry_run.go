@@ -0,0 +1,97 @@
+package geckoboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// dryRunDatasetService records what would be sent to Geckoboard
+// instead of sending it, printing the schema and each batch
+type dryRunDatasetService struct {
+	client           *Client
+	out              io.Writer
+	dir              string
+	maxRecordsPerReq int
+}
+
+// DryRun swaps the dataset service for one which prints the schema and
+// batches that would be sent to Geckoboard without sending them. When dir
+// isn't empty each payload is also written to a file in it to inspect
+func (c *Client) DryRun(out io.Writer, dir string) error {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	c.DatasetService = &dryRunDatasetService{
+		client:           c,
+		out:              out,
+		dir:              dir,
+		maxRecordsPerReq: maxRecordsPerRequest,
+	}
+
+	return nil
+}
+
+func (d *dryRunDatasetService) FindOrCreate(_ context.Context, dataset *Dataset) error {
+	if err := dataset.Validate(); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(dataset)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(d.out, "Dry run: would create dataset %s with schema %s\n", dataset.Name, b)
+	return d.writePayload(dataset.Name+".schema.json", b)
+}
+
+// AppendData validates and batches the data the same way pushing it would,
+// so the rows rejected and the batches are the ones a real push would have
+func (d *dryRunDatasetService) AppendData(_ context.Context, dataset *Dataset, data Data) (AppendResult, error) {
+	data, rejected := validateData(dataset, data, d.client.ValidationPolicy)
+	result := AppendResult{Rejected: rejected}
+
+	batches := (len(data) + d.maxRecordsPerReq - 1) / d.maxRecordsPerReq
+
+	for start := 0; start < len(data); start += d.maxRecordsPerReq {
+		end := start + d.maxRecordsPerReq
+		if end > len(data) {
+			end = len(data)
+		}
+
+		result.Batches++
+
+		b, err := json.Marshal(DataPayload{Data: data[start:end]})
+		if err != nil {
+			return result, err
+		}
+
+		fmt.Fprintf(d.out, "Dry run: would push batch %d of %d to %s with %d records (%d bytes)\n",
+			result.Batches, batches, dataset.Name, end-start, len(b))
+
+		name := fmt.Sprintf("%s.batch-%03d.json", dataset.Name, result.Batches)
+		if err := d.writePayload(name, b); err != nil {
+			return result, err
+		}
+
+		result.Sent++
+		result.RecordsSent += end - start
+	}
+
+	return result, nil
+}
+
+func (d *dryRunDatasetService) writePayload(name string, b []byte) error {
+	if d.dir == "" {
+		return nil
+	}
+
+	return os.WriteFile(filepath.Join(d.dir, name), b, 0o644)
+}
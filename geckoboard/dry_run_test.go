@@ -0,0 +1,67 @@
+package geckoboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestClient_DryRun(t *testing.T) {
+	dataset := &Dataset{
+		Name: "bullhorn-placements",
+		Fields: map[string]Field{
+			"id": {Name: "ID", Type: StringType},
+		},
+		UniqueBy: []string{"id"},
+	}
+
+	t.Run("prints the schema and batches without sending them", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		dir := filepath.Join(t.TempDir(), "payloads")
+
+		c := New("http://127.0.0.1:0", "key")
+		assert.NilError(t, c.DryRun(out, dir))
+		c.DatasetService.(*dryRunDatasetService).maxRecordsPerReq = 2
+
+		assert.NilError(t, c.DatasetService.FindOrCreate(context.Background(), dataset))
+
+		data := Data{{"id": "1"}, {"id": "2"}, {"id": "3"}, {"title": "missing id"}}
+		result, err := c.DatasetService.AppendData(context.Background(), dataset, data)
+		assert.NilError(t, err)
+		assert.Equal(t, result.Batches, 2)
+		assert.Equal(t, result.Sent, 2)
+		assert.Equal(t, result.RecordsSent, 3)
+		assert.DeepEqual(t, result.Rejected, []RejectedRow{{ID: "row 4", Reasons: []string{`field "id" is required`}}})
+
+		assert.Equal(t, out.String(), ""+
+			`Dry run: would create dataset bullhorn-placements with schema {"id":"bullhorn-placements","fields":{"id":{"type":"string","name":"ID","optional":false}},"unique_by":["id"]}`+"\n"+
+			"Dry run: would push batch 1 of 2 to bullhorn-placements with 2 records (32 bytes)\n"+
+			"Dry run: would push batch 2 of 2 to bullhorn-placements with 1 records (21 bytes)\n")
+
+		for name, want := range map[string]string{
+			"bullhorn-placements.schema.json":    `{"id":"bullhorn-placements","fields":{"id":{"type":"string","name":"ID","optional":false}},"unique_by":["id"]}`,
+			"bullhorn-placements.batch-001.json": `{"data":[{"id":"1"},{"id":"2"}]}`,
+			"bullhorn-placements.batch-002.json": `{"data":[{"id":"3"}]}`,
+		} {
+			got, err := os.ReadFile(filepath.Join(dir, name))
+			assert.NilError(t, err)
+			assert.Equal(t, string(got), want, fmt.Sprintf("payload %s", name))
+		}
+	})
+
+	t.Run("returns error for an invalid schema", func(t *testing.T) {
+		c := New("", "")
+		assert.NilError(t, c.DryRun(&bytes.Buffer{}, ""))
+
+		err := c.DatasetService.FindOrCreate(context.Background(), &Dataset{
+			Name:   "bullhorn-placements",
+			Fields: map[string]Field{"salary": {Name: "Salary", Type: MoneyType}},
+		})
+		assert.Error(t, err, `dataset bullhorn-placements: money field "Salary" requires a three letter currency code`)
+	})
+}
@@ -1,17 +1,68 @@
 package geckoboard
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 type Error struct {
 	Detail     `json:"error"`
 	StatusCode int `json:"-"`
+
+	// Body is the response body as Geckoboard sent it
+	Body string `json:"-"`
 }
 
 type Detail struct {
 	Message string `json:"message"`
+	Type    string `json:"type,omitempty"`
+
+	// Details is any extra information about the error
+	// such as the fields which failed validation
+	Details json.RawMessage `json:"details,omitempty"`
 }
 
 func (e Error) Error() string {
 	template := "There was an error sending the data to Geckoboard's API: %q: with response code %d"
-	return fmt.Sprintf(template, e.Detail.Message, e.StatusCode)
+	message := e.Detail.Message
+	if message == "" {
+		message = truncateBody(e.Body)
+	}
+
+	if message == "" && e.StatusCode >= http.StatusInternalServerError {
+		message = errUnexpectedResponse.Error()
+	}
+
+	msg := fmt.Sprintf(template, message, e.StatusCode)
+
+	if len(e.Details) > 0 {
+		msg += fmt.Sprintf(": details %s", e.Details)
+	}
+
+	return msg
+}
+
+// Unwrap returns errUnexpectedResponse for server errors, so they
+// can still be told apart from the errors of a bad request
+func (e Error) Unwrap() error {
+	if e.StatusCode >= http.StatusInternalServerError {
+		return errUnexpectedResponse
+	}
+
+	return nil
+}
+
+// truncateBody keeps the message of a body which isn't a Geckoboard error short
+func truncateBody(body string) string {
+	const max = 200
+
+	// Cut on runes so a multi-byte character isn't split
+	runes := []rune(strings.TrimSpace(body))
+	if len(runes) > max {
+		return string(runes[:max]) + "..."
+	}
+
+	return string(runes)
 }
@@ -1,6 +1,8 @@
 package geckoboard
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -16,3 +18,33 @@ func TestError_Error(t *testing.T) {
 
 	assert.Equal(t, err.Error(), `There was an error sending the data to Geckoboard's API: "missing field type": with response code 400`)
 }
+
+func TestError_ErrorWithDetails(t *testing.T) {
+	err := Error{
+		StatusCode: 400,
+		Detail: Detail{
+			Message: "invalid data",
+			Details: json.RawMessage(`{"fields":{"fee":"must be a number"}}`),
+		},
+	}
+
+	assert.Equal(t, err.Error(), `There was an error sending the data to Geckoboard's API: "invalid data": with response code 400: details {"fields":{"fee":"must be a number"}}`)
+}
+
+func TestError_Unwrap(t *testing.T) {
+	assert.ErrorIs(t, Error{StatusCode: 502}, errUnexpectedResponse)
+	assert.Assert(t, Error{StatusCode: 400}.Unwrap() == nil)
+}
+
+func TestTruncateBody(t *testing.T) {
+	t.Run("returns a short body as it is", func(t *testing.T) {
+		assert.Equal(t, truncateBody("  Bad gateway\n"), "Bad gateway")
+	})
+
+	t.Run("cuts a long body on runes", func(t *testing.T) {
+		body := strings.Repeat("a", 199) + strings.Repeat("é", 10)
+
+		got := truncateBody(body)
+		assert.Equal(t, got, strings.Repeat("a", 199)+"é...")
+	})
+}
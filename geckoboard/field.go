@@ -0,0 +1,81 @@
+package geckoboard
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type TimeUnit string
+
+const (
+	Milliseconds TimeUnit = "milliseconds"
+	Seconds      TimeUnit = "seconds"
+	Minutes      TimeUnit = "minutes"
+	Hours        TimeUnit = "hours"
+)
+
+// DateFormat is the layout Geckoboard expects for date fields
+const DateFormat = "2006-01-02"
+
+// Validate returns an error when a field is missing the
+// extra attributes its type requires
+func (f Field) Validate() error {
+	switch f.Type {
+	case MoneyType:
+		if len(f.CurrencyCode) != 3 {
+			return fmt.Errorf("money field %q requires a three letter currency code", f.Name)
+		}
+	case DurationType:
+		switch f.TimeUnit {
+		case Milliseconds, Seconds, Minutes, Hours:
+		default:
+			return fmt.Errorf("duration field %q requires a time unit of milliseconds, seconds, minutes or hours", f.Name)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks each of the dataset fields are valid
+func (d *Dataset) Validate() error {
+	// Sorted so the same field is always reported first
+	for _, k := range sortedFieldKeys(d.Fields) {
+		if err := d.Fields[k].Validate(); err != nil {
+			return fmt.Errorf("dataset %s: %w", d.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// MoneyValue converts an amount into the minor currency units Geckoboard
+// expects for money fields, so 12.50 becomes 1250
+func MoneyValue(amount float64) int {
+	return int(math.Round(amount * 100))
+}
+
+// DateValue formats the time as a date, returning nil for a zero
+// time so the field is left empty rather than set to year one
+func DateValue(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+
+	v := t.Format(DateFormat)
+	return &v
+}
+
+// DurationValue converts the duration into the time unit of the field
+func DurationValue(d time.Duration, unit TimeUnit) float64 {
+	switch unit {
+	case Milliseconds:
+		return float64(d.Milliseconds())
+	case Seconds:
+		return d.Seconds()
+	case Minutes:
+		return d.Minutes()
+	}
+
+	return d.Hours()
+}
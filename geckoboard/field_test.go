@@ -0,0 +1,110 @@
+package geckoboard
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestField_Validate(t *testing.T) {
+	specs := []struct {
+		name    string
+		in      Field
+		wantErr string
+	}{
+		{
+			name: "string field is valid",
+			in:   Field{Name: "Title", Type: StringType},
+		},
+		{
+			name: "money field with currency code is valid",
+			in:   Field{Name: "Salary", Type: MoneyType, CurrencyCode: "USD"},
+		},
+		{
+			name:    "money field without currency code",
+			in:      Field{Name: "Salary", Type: MoneyType},
+			wantErr: `money field "Salary" requires a three letter currency code`,
+		},
+		{
+			name: "duration field with time unit is valid",
+			in:   Field{Name: "Time to fill", Type: DurationType, TimeUnit: Hours},
+		},
+		{
+			name:    "duration field without time unit",
+			in:      Field{Name: "Time to fill", Type: DurationType},
+			wantErr: `duration field "Time to fill" requires a time unit of milliseconds, seconds, minutes or hours`,
+		},
+		{
+			name:    "duration field with unknown time unit",
+			in:      Field{Name: "Time to fill", Type: DurationType, TimeUnit: "days"},
+			wantErr: `duration field "Time to fill" requires a time unit of milliseconds, seconds, minutes or hours`,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			err := spec.in.Validate()
+			if spec.wantErr == "" {
+				assert.NilError(t, err)
+				return
+			}
+
+			assert.Error(t, err, spec.wantErr)
+		})
+	}
+}
+
+func TestDataset_Validate(t *testing.T) {
+	t.Run("returns nil when all fields are valid", func(t *testing.T) {
+		ds := &Dataset{
+			Name: "bullhorn-test",
+			Fields: map[string]Field{
+				"id":     {Name: "ID", Type: StringType},
+				"salary": {Name: "Salary", Type: MoneyType, CurrencyCode: "GBP"},
+			},
+		}
+
+		assert.NilError(t, ds.Validate())
+	})
+
+	t.Run("returns the first invalid field error", func(t *testing.T) {
+		ds := &Dataset{
+			Name: "bullhorn-test",
+			Fields: map[string]Field{
+				"salary":   {Name: "Salary", Type: MoneyType},
+				"duration": {Name: "Duration", Type: DurationType},
+			},
+		}
+
+		err := ds.Validate()
+		assert.Error(t, err, `dataset bullhorn-test: duration field "Duration" requires a time unit of milliseconds, seconds, minutes or hours`)
+	})
+}
+
+func TestMoneyValue(t *testing.T) {
+	assert.Equal(t, MoneyValue(0), 0)
+	assert.Equal(t, MoneyValue(12.5), 1250)
+	assert.Equal(t, MoneyValue(19.999), 2000)
+	assert.Equal(t, MoneyValue(-3.21), -321)
+}
+
+func TestDateValue(t *testing.T) {
+	t.Run("returns nil for zero time", func(t *testing.T) {
+		assert.Assert(t, DateValue(time.Time{}) == nil)
+	})
+
+	t.Run("returns the date", func(t *testing.T) {
+		got := DateValue(time.Date(2022, 7, 29, 16, 13, 54, 0, time.UTC))
+		assert.Equal(t, *got, "2022-07-29")
+	})
+}
+
+func TestDurationValue(t *testing.T) {
+	d := 90 * time.Minute
+
+	assert.Equal(t, DurationValue(d, Milliseconds), float64(5400000))
+	assert.Equal(t, DurationValue(d, Seconds), float64(5400))
+	assert.Equal(t, DurationValue(d, Minutes), float64(90))
+	assert.Equal(t, DurationValue(d, Hours), 1.5)
+}
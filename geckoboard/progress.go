@@ -0,0 +1,104 @@
+package geckoboard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Progress is how far through pushing a dataset's data the last run got
+type Progress struct {
+	// Fingerprint identifies the data being pushed so progress is only
+	// resumed when the data hasn't changed since the last run
+	Fingerprint string `json:"fingerprint"`
+	BatchesSent int    `json:"batches_sent"`
+}
+
+// ProgressStore keeps the upload progress of each dataset between runs
+type ProgressStore interface {
+	Load(dataset string) (*Progress, error)
+	Save(dataset string, progress Progress) error
+	Clear(dataset string) error
+}
+
+// FileProgressStore keeps the progress of each dataset in its own file
+type FileProgressStore struct {
+	dir string
+}
+
+// NewFileProgressStore creates the state directory when it doesn't exist
+func NewFileProgressStore(dir string) (*FileProgressStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileProgressStore{dir: dir}, nil
+}
+
+func (s *FileProgressStore) path(dataset string) string {
+	return filepath.Join(s.dir, dataset+".progress.json")
+}
+
+// Load returns nil when there is no progress for the dataset
+func (s *FileProgressStore) Load(dataset string) (*Progress, error) {
+	b, err := os.ReadFile(s.path(dataset))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &Progress{}
+	if err := json.Unmarshal(b, progress); err != nil {
+		return nil, fmt.Errorf("reading progress of dataset %s: %w", dataset, err)
+	}
+
+	return progress, nil
+}
+
+// Save writes to a temporary file first so a run stopped part
+// way through writing doesn't leave a corrupt progress file
+func (s *FileProgressStore) Save(dataset string, progress Progress) error {
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path(dataset) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path(dataset))
+}
+
+func (s *FileProgressStore) Clear(dataset string) error {
+	err := os.Remove(s.path(dataset))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// fingerprint hashes the data along with the batch size, as
+// the batches sent only line up when both are the same
+func fingerprint(data Data, batchSize int) (string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(strconv.Itoa(batchSize)))
+	h.Write(b)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
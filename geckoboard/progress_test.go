@@ -0,0 +1,69 @@
+package geckoboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFileProgressStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+
+	store, err := NewFileProgressStore(dir)
+	assert.NilError(t, err)
+
+	t.Run("returns nil when there is no progress", func(t *testing.T) {
+		got, err := store.Load("bullhorn-placements")
+		assert.NilError(t, err)
+		assert.Assert(t, got == nil)
+	})
+
+	t.Run("saves and loads the progress", func(t *testing.T) {
+		want := Progress{Fingerprint: "abc", BatchesSent: 6}
+		assert.NilError(t, store.Save("bullhorn-placements", want))
+
+		got, err := store.Load("bullhorn-placements")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, &want)
+
+		_, err = os.Stat(filepath.Join(dir, "bullhorn-placements.progress.json.tmp"))
+		assert.Assert(t, os.IsNotExist(err))
+	})
+
+	t.Run("clears the progress", func(t *testing.T) {
+		assert.NilError(t, store.Clear("bullhorn-placements"))
+		assert.NilError(t, store.Clear("bullhorn-placements"))
+
+		got, err := store.Load("bullhorn-placements")
+		assert.NilError(t, err)
+		assert.Assert(t, got == nil)
+	})
+
+	t.Run("returns error when the progress file is corrupt", func(t *testing.T) {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, "bullhorn-users.progress.json"), []byte("{"), 0o644))
+
+		_, err := store.Load("bullhorn-users")
+		assert.ErrorContains(t, err, "reading progress of dataset bullhorn-users")
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	data := Data{{"id": "1", "title": "one"}, {"id": "2"}}
+
+	a, err := fingerprint(data, 500)
+	assert.NilError(t, err)
+
+	b, err := fingerprint(Data{{"title": "one", "id": "1"}, {"id": "2"}}, 500)
+	assert.NilError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := fingerprint(data, 200)
+	assert.NilError(t, err)
+	assert.Assert(t, a != c)
+
+	d, err := fingerprint(Data{{"id": "1", "title": "two"}, {"id": "2"}}, 500)
+	assert.NilError(t, err)
+	assert.Assert(t, a != d)
+}
@@ -0,0 +1,254 @@
+package geckoboard
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ValidationPolicy decides what happens to rows
+// which don't match the dataset schema
+type ValidationPolicy string
+
+const (
+	// FixInvalidRows truncates long strings, removes unknown fields
+	// and empties invalid optional values, rows which still can't
+	// be pushed such as ones missing a required field are dropped
+	FixInvalidRows ValidationPolicy = "fix"
+	// DropInvalidRows drops any row which doesn't match the schema
+	DropInvalidRows ValidationPolicy = "drop"
+)
+
+// MaxNumber is the largest number Geckoboard can store without losing precision
+const MaxNumber = 1 << 53
+
+// ParseValidationPolicy returns the policy for the value, an empty value is fix
+func ParseValidationPolicy(v string) (ValidationPolicy, error) {
+	switch p := ValidationPolicy(strings.ToLower(v)); p {
+	case "", FixInvalidRows:
+		return FixInvalidRows, nil
+	case DropInvalidRows:
+		return p, nil
+	}
+
+	return "", fmt.Errorf("unknown invalid rows policy %q, only fix and drop are valid", v)
+}
+
+// RejectedRow is a row which was not pushed and the reasons why
+type RejectedRow struct {
+	ID      string
+	Reasons []string
+}
+
+// validateData checks each row against the dataset fields returning the rows
+// to push and the ones rejected. Without any fields there is nothing to check
+func validateData(dataset *Dataset, data Data, policy ValidationPolicy) (Data, []RejectedRow) {
+	if len(dataset.Fields) == 0 {
+		return data, nil
+	}
+
+	valid := Data{}
+	rejected := []RejectedRow{}
+
+	for i, row := range data {
+		fixed, reasons, ok := validateRow(dataset.Fields, row, policy)
+		if ok {
+			valid = append(valid, fixed)
+			continue
+		}
+
+		rejected = append(rejected, RejectedRow{
+			ID:      rowID(dataset, row, i),
+			Reasons: reasons,
+		})
+	}
+
+	return valid, rejected
+}
+
+// validateRow returns a copy of the row with any fixes the policy allows,
+// ok is false when the row has to be dropped
+func validateRow(fields map[string]Field, row DataRow, policy ValidationPolicy) (DataRow, []string, bool) {
+	fixed := DataRow{}
+	reasons := []string{}
+	ok := true
+
+	reject := func(reason string) {
+		reasons = append(reasons, reason)
+		ok = false
+	}
+
+	for _, key := range sortedKeys(row) {
+		if _, known := fields[key]; !known {
+			if policy == DropInvalidRows {
+				reject(fmt.Sprintf("unknown field %q", key))
+			}
+
+			continue
+		}
+
+		fixed[key] = row[key]
+	}
+
+	for _, key := range sortedFieldKeys(fields) {
+		field := fields[key]
+		val := Indirect(fixed[key])
+
+		if val == nil {
+			if !field.Optional {
+				reject(fmt.Sprintf("field %q is required", key))
+			}
+
+			continue
+		}
+
+		fixedVal, reason := validateValue(field, val)
+		if reason == "" {
+			continue
+		}
+
+		switch {
+		case policy == DropInvalidRows:
+			reject(fmt.Sprintf("field %q %s", key, reason))
+		case fixedVal != nil:
+			fixed[key] = fixedVal
+		case field.Optional:
+			fixed[key] = nil
+		default:
+			reject(fmt.Sprintf("field %q %s", key, reason))
+		}
+	}
+
+	return fixed, reasons, ok
+}
+
+// validateValue returns the reason the value isn't valid for the field
+// along with a fixed value when there is a way to fix it
+func validateValue(field Field, val interface{}) (interface{}, string) {
+	switch field.Type {
+	case StringType:
+		s, isString := val.(string)
+		if !isString {
+			return nil, "must be a string"
+		}
+
+		if len([]rune(s)) > MaxStringLength {
+			return string([]rune(s)[:MaxStringLength]), fmt.Sprintf("is longer than %d characters", MaxStringLength)
+		}
+	case NumberType, PercentType, DurationType:
+		n, isNumber := ToFloat(val)
+		if !isNumber {
+			return nil, "must be a number"
+		}
+
+		if math.IsNaN(n) || math.IsInf(n, 0) || math.Abs(n) > MaxNumber {
+			return nil, "is out of range"
+		}
+	case MoneyType:
+		n, isNumber := ToFloat(val)
+		if !isNumber || n != math.Trunc(n) {
+			return nil, "must be a whole number of minor currency units"
+		}
+
+		if math.Abs(n) > MaxNumber {
+			return nil, "is out of range"
+		}
+	case DatetimeType:
+		s, isString := val.(string)
+		if _, err := time.Parse(time.RFC3339, s); !isString || err != nil {
+			return nil, "must be an RFC 3339 datetime"
+		}
+	case DateType:
+		s, isString := val.(string)
+		if _, err := time.Parse(DateFormat, s); !isString || err != nil {
+			return nil, "must be a date formatted as YYYY-MM-DD"
+		}
+	}
+
+	return nil, ""
+}
+
+// RowKey returns the values of the dataset's unique by fields for the
+// row, or the id when there are none, empty when the row has no values
+func (d *Dataset) RowKey(row DataRow) string {
+	keys := d.UniqueBy
+	if len(keys) == 0 {
+		keys = []string{"id"}
+	}
+
+	ids := []string{}
+	for _, k := range keys {
+		if v := Indirect(row[k]); v != nil {
+			ids = append(ids, fmt.Sprint(v))
+		}
+	}
+
+	return strings.Join(ids, ",")
+}
+
+func rowID(dataset *Dataset, row DataRow, index int) string {
+	if key := dataset.RowKey(row); key != "" {
+		return key
+	}
+
+	return fmt.Sprintf("row %d", index+1)
+}
+
+// Indirect returns the value a pointer points to, or nil for a nil
+// pointer, as rows use pointers for values which can be empty
+func Indirect(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	return rv.Interface()
+}
+
+// ToFloat returns the value of any of the int, uint or float
+// kinds as a float64, ok is false for any other kind
+func ToFloat(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+
+	return 0, false
+}
+
+func sortedKeys(row DataRow) []string {
+	keys := []string{}
+	for k := range row {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedFieldKeys(fields map[string]Field) []string {
+	keys := []string{}
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
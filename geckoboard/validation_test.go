@@ -0,0 +1,119 @@
+package geckoboard
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var validationDataset = &Dataset{
+	Name: "bullhorn-test",
+	Fields: map[string]Field{
+		"id":         {Name: "ID", Type: StringType},
+		"title":      {Name: "Title", Type: StringType, Optional: true},
+		"fee":        {Name: "Fee", Type: NumberType, Optional: true},
+		"salary":     {Name: "Salary", Type: MoneyType, Optional: true, CurrencyCode: "USD"},
+		"created_at": {Name: "Created at", Type: DatetimeType, Optional: true},
+		"start_date": {Name: "Start date", Type: DateType, Optional: true},
+	},
+	UniqueBy: []string{"id"},
+}
+
+func TestParseValidationPolicy(t *testing.T) {
+	for in, want := range map[string]ValidationPolicy{"": FixInvalidRows, "fix": FixInvalidRows, "DROP": DropInvalidRows} {
+		got, err := ParseValidationPolicy(in)
+		assert.NilError(t, err)
+		assert.Equal(t, got, want)
+	}
+
+	_, err := ParseValidationPolicy("ignore")
+	assert.Error(t, err, `unknown invalid rows policy "ignore", only fix and drop are valid`)
+}
+
+func TestValidateData(t *testing.T) {
+	title := "Valid title"
+	longTitle := strings.Repeat("a", 300)
+
+	data := Data{
+		{"id": "1", "title": &title, "fee": 12.5, "salary": 500000, "created_at": "2022-07-29T16:13:54Z", "start_date": "2022-08-01"},
+		{"id": "2", "title": longTitle, "extra": "unknown"},
+		{"id": "3", "fee": "twelve", "salary": 12.5, "created_at": "yesterday", "start_date": "01/08/2022"},
+		{"id": "4", "fee": math.Inf(1)},
+		{"title": "missing id", "id": (*string)(nil)},
+	}
+
+	t.Run("fixes what it can and drops the rest", func(t *testing.T) {
+		valid, rejected := validateData(validationDataset, data, FixInvalidRows)
+
+		assert.DeepEqual(t, valid, Data{
+			{"id": "1", "title": &title, "fee": 12.5, "salary": 500000, "created_at": "2022-07-29T16:13:54Z", "start_date": "2022-08-01"},
+			{"id": "2", "title": strings.Repeat("a", 255)},
+			{"id": "3", "fee": nil, "salary": nil, "created_at": nil, "start_date": nil},
+			{"id": "4", "fee": nil},
+		})
+		assert.DeepEqual(t, rejected, []RejectedRow{
+			{ID: "row 5", Reasons: []string{`field "id" is required`}},
+		})
+	})
+
+	t.Run("drops every invalid row", func(t *testing.T) {
+		valid, rejected := validateData(validationDataset, data, DropInvalidRows)
+
+		assert.DeepEqual(t, valid, Data{data[0]})
+		assert.DeepEqual(t, rejected, []RejectedRow{
+			{ID: "2", Reasons: []string{`unknown field "extra"`, `field "title" is longer than 255 characters`}},
+			{ID: "3", Reasons: []string{
+				`field "created_at" must be an RFC 3339 datetime`,
+				`field "fee" must be a number`,
+				`field "salary" must be a whole number of minor currency units`,
+				`field "start_date" must be a date formatted as YYYY-MM-DD`,
+			}},
+			{ID: "4", Reasons: []string{`field "fee" is out of range`}},
+			{ID: "row 5", Reasons: []string{`field "id" is required`}},
+		})
+	})
+
+	t.Run("drops a required field it can't fix", func(t *testing.T) {
+		dataset := &Dataset{Fields: map[string]Field{"id": {Name: "ID", Type: NumberType}}}
+
+		valid, rejected := validateData(dataset, Data{{"id": "abc"}}, FixInvalidRows)
+		assert.DeepEqual(t, valid, Data{})
+		assert.DeepEqual(t, rejected, []RejectedRow{
+			{ID: "abc", Reasons: []string{`field "id" must be a number`}},
+		})
+	})
+
+	t.Run("doesn't validate without dataset fields", func(t *testing.T) {
+		valid, rejected := validateData(&Dataset{Name: "no-fields"}, data, DropInvalidRows)
+		assert.DeepEqual(t, valid, data)
+		assert.Assert(t, rejected == nil)
+	})
+
+	t.Run("doesn't change the given rows", func(t *testing.T) {
+		row := DataRow{"id": "2", "title": longTitle}
+		validateData(validationDataset, Data{row}, FixInvalidRows)
+		assert.Equal(t, row["title"], longTitle)
+	})
+}
+
+func TestDataset_RowKey(t *testing.T) {
+	id := "42"
+	var missing *string
+
+	t.Run("returns the id when there are no unique by fields", func(t *testing.T) {
+		d := &Dataset{}
+		assert.Equal(t, d.RowKey(DataRow{"id": &id}), "42")
+	})
+
+	t.Run("joins the unique by values", func(t *testing.T) {
+		d := &Dataset{UniqueBy: []string{"id", "date"}}
+		assert.Equal(t, d.RowKey(DataRow{"id": 7, "date": "2022-05-05"}), "7,2022-05-05")
+	})
+
+	t.Run("returns empty when the row has no values", func(t *testing.T) {
+		d := &Dataset{UniqueBy: []string{"id"}}
+		assert.Equal(t, d.RowKey(DataRow{"id": missing}), "")
+	})
+}
@@ -0,0 +1,116 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+// Run is the report of a single run of one or more datasets
+type Run struct {
+	ID    int       `json:"id"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	OK    bool      `json:"ok"`
+	// Error is why the run failed before pushing any datasets
+	Error    string       `json:"error,omitempty"`
+	Datasets []DatasetRun `json:"datasets"`
+	// Requests is the number of API calls made to each service, and
+	// RequestErrors the ones which failed or didn't succeed
+	Requests      map[string]int `json:"requests,omitempty"`
+	RequestErrors map[string]int `json:"request_errors,omitempty"`
+}
+
+// DatasetRun is how pushing a single dataset went
+type DatasetRun struct {
+	Name     string        `json:"name"`
+	OK       bool          `json:"ok"`
+	Duration time.Duration `json:"duration"`
+	// Records is the number of records written to each sink
+	Records map[string]int `json:"records,omitempty"`
+	Errors  []string       `json:"errors,omitempty"`
+}
+
+// Duration is how long the run took
+func (r Run) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Records is the number of records written to every sink
+func (r Run) Records() int {
+	total := 0
+	for _, d := range r.Datasets {
+		for _, n := range d.Records {
+			total += n
+		}
+	}
+
+	return total
+}
+
+// FailedDatasets is the number of datasets which failed
+func (r Run) FailedDatasets() int {
+	failed := 0
+	for _, d := range r.Datasets {
+		if !d.OK {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// Failing is a dataset which failed the latest runs it was part of
+type Failing struct {
+	Dataset string
+	// Runs is the number of runs in a row the dataset has failed
+	Runs  int
+	Since time.Time
+	// LastError is the last error the dataset failed with
+	LastError string
+}
+
+// FailingDatasets returns the datasets which failed the last time they
+// were pushed, going back through the runs to count how long for.
+// The runs have to be oldest first, the failing datasets are sorted by name
+func FailingDatasets(runs []Run) []Failing {
+	failing := map[string]*Failing{}
+	// done is set once a dataset's latest streak of failures has been counted
+	done := map[string]bool{}
+
+	for i := len(runs) - 1; i >= 0; i-- {
+		for _, d := range runs[i].Datasets {
+			if done[d.Name] {
+				continue
+			}
+
+			if d.OK {
+				done[d.Name] = true
+				continue
+			}
+
+			f, found := failing[d.Name]
+			if !found {
+				f = &Failing{Dataset: d.Name}
+				if len(d.Errors) > 0 {
+					f.LastError = d.Errors[len(d.Errors)-1]
+				}
+
+				failing[d.Name] = f
+			}
+
+			f.Runs++
+			f.Since = runs[i].Start
+		}
+	}
+
+	list := []Failing{}
+	for _, f := range failing {
+		list = append(list, *f)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Dataset < list[j].Dataset
+	})
+
+	return list
+}
@@ -0,0 +1,109 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	r := newRecorder(func() time.Time { return now })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: r.Transport("bullhorn", nil)}
+	for _, path := range []string{"/", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		assert.NilError(t, err)
+		resp.Body.Close()
+	}
+
+	r.RecordWrite("bullhorn-placements", "geckoboard", 10)
+	r.RecordWrite("bullhorn-placements", "csv", 20)
+	r.RecordDataset("bullhorn-placements", 2*time.Second, true)
+	r.RecordError("bullhorn-contacts", errors.New("fetching data: timeout"))
+	r.RecordDataset("bullhorn-contacts", time.Second, false)
+
+	now = now.Add(time.Minute)
+	r.RecordCycle(false)
+
+	assert.DeepEqual(t, r.Run(), Run{
+		Start: time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC),
+		End:   time.Date(2022, 6, 1, 10, 1, 0, 0, time.UTC),
+		OK:    false,
+		Datasets: []DatasetRun{
+			{
+				Name:     "bullhorn-placements",
+				OK:       true,
+				Duration: 2 * time.Second,
+				Records:  map[string]int{"geckoboard": 10, "csv": 20},
+			},
+			{
+				Name:     "bullhorn-contacts",
+				OK:       false,
+				Duration: time.Second,
+				Records:  map[string]int{},
+				Errors:   []string{"fetching data: timeout"},
+			},
+		},
+		Requests:      map[string]int{"bullhorn": 2},
+		RequestErrors: map[string]int{"bullhorn": 1},
+	})
+}
+
+func TestRecorder_Fail(t *testing.T) {
+	r := NewRecorder()
+
+	err := &url.Error{Op: "Get", URL: "https://bullhorn.example.com/login?username=a&password=secret", Err: errors.New("timeout")}
+	r.Fail(fmt.Errorf("logging in to bullhorn: %w", err))
+
+	run := r.Run()
+	assert.Assert(t, !run.OK)
+	assert.Equal(t, run.Error, `logging in to bullhorn: Get "https://bullhorn.example.com/login": timeout`)
+}
+
+func TestFailingDatasets(t *testing.T) {
+	start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	run := func(i int, datasets ...DatasetRun) Run {
+		return Run{ID: i + 1, Start: start.Add(time.Duration(i) * 15 * time.Minute), Datasets: datasets}
+	}
+
+	ok := func(name string) DatasetRun {
+		return DatasetRun{Name: name, OK: true}
+	}
+
+	failed := func(name, err string) DatasetRun {
+		return DatasetRun{Name: name, Errors: []string{err}}
+	}
+
+	runs := []Run{
+		run(0, failed("bullhorn-contacts", "old"), failed("bullhorn-placements", "timeout")),
+		run(1, ok("bullhorn-contacts"), failed("bullhorn-placements", "timeout")),
+		run(2, failed("bullhorn-contacts", "rate limited"), failed("bullhorn-placements", "bad gateway")),
+		// Only some datasets pushed by this run
+		run(3, ok("bullhorn-users")),
+		run(4, failed("bullhorn-contacts", "unauthorized")),
+	}
+
+	assert.DeepEqual(t, FailingDatasets(runs), []Failing{
+		{Dataset: "bullhorn-contacts", Runs: 2, Since: start.Add(30 * time.Minute), LastError: "unauthorized"},
+		{Dataset: "bullhorn-placements", Runs: 3, Since: start, LastError: "bad gateway"},
+	})
+
+	assert.DeepEqual(t, FailingDatasets(runs[:2]), []Failing{
+		{Dataset: "bullhorn-placements", Runs: 2, Since: start, LastError: "timeout"},
+	})
+}
@@ -0,0 +1,150 @@
+package history
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Recorder builds the report of a run from the pushes
+// of a processor and the requests made during it
+type Recorder struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	run      Run
+	datasets map[string]*DatasetRun
+	// order is the names of the datasets in the order they were pushed
+	order []string
+}
+
+func NewRecorder() *Recorder {
+	return newRecorder(time.Now)
+}
+
+func newRecorder(now func() time.Time) *Recorder {
+	return &Recorder{
+		now: now,
+		run: Run{
+			Start:         now(),
+			Requests:      map[string]int{},
+			RequestErrors: map[string]int{},
+		},
+		datasets: map[string]*DatasetRun{},
+	}
+}
+
+func (r *Recorder) RecordWrite(dataset, sink string, records int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.dataset(dataset)
+	d.Records[sink] += records
+}
+
+func (r *Recorder) RecordError(dataset string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.dataset(dataset)
+	d.Errors = append(d.Errors, bullhorn.RedactedError(err))
+}
+
+func (r *Recorder) RecordDataset(dataset string, duration time.Duration, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.dataset(dataset)
+	d.Duration = duration
+	d.OK = ok
+}
+
+// RecordCycle finishes the run
+func (r *Recorder) RecordCycle(ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.End = r.now()
+	r.run.OK = ok
+}
+
+// Fail finishes the run when it failed before pushing any datasets
+func (r *Recorder) Fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.End = r.now()
+	r.run.OK = false
+	r.run.Error = bullhorn.RedactedError(err)
+}
+
+// Run returns the report of the run, the datasets are in the order they were pushed
+func (r *Recorder) Run() Run {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run := r.run
+	if run.End.IsZero() {
+		run.End = r.now()
+	}
+
+	run.Datasets = []DatasetRun{}
+	for _, name := range r.order {
+		run.Datasets = append(run.Datasets, *r.datasets[name])
+	}
+
+	return run
+}
+
+// dataset returns the dataset's report, adding it the first time it's seen
+func (r *Recorder) dataset(name string) *DatasetRun {
+	if d, ok := r.datasets[name]; ok {
+		return d
+	}
+
+	d := &DatasetRun{Name: name, Records: map[string]int{}}
+	r.datasets[name] = d
+	r.order = append(r.order, name)
+
+	return d
+}
+
+type transport struct {
+	service  string
+	next     http.RoundTripper
+	recorder *Recorder
+}
+
+// Transport counts the requests sent through it for the service, sending
+// them on with next or the default transport when it's nil
+func (r *Recorder) Transport(service string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{service: service, next: next, recorder: r}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	code := 0
+	if err == nil {
+		code = resp.StatusCode
+	}
+
+	t.recorder.recordRequest(t.service, code)
+	return resp, err
+}
+
+// recordRequest counts the request, a status code of 0 being one which got no response
+func (r *Recorder) recordRequest(service string, code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.Requests[service]++
+	if code == 0 || code >= 400 {
+		r.run.RequestErrors[service]++
+	}
+}
@@ -0,0 +1,182 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+// WriteRuns writes a table of the latest runs up to the limit, newest first,
+// or of every run when it's zero. It's followed by the datasets failing as of
+// the latest run, which are worked out from every run, not only the ones listed
+func WriteRuns(w io.Writer, runs []Run, limit int) error {
+	if len(runs) == 0 {
+		_, err := fmt.Fprintln(w, "No runs yet")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTARTED\tDURATION\tSTATUS\tDATASETS\tRECORDS\tREQUESTS")
+
+	listed := runs
+	if limit > 0 && len(runs) > limit {
+		listed = runs[len(runs)-limit:]
+	}
+
+	for i := len(listed) - 1; i >= 0; i-- {
+		r := listed[i]
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			r.ID,
+			r.Start.Format(timeFormat),
+			r.Duration().Round(time.Second),
+			status(r.OK),
+			datasetsSummary(r),
+			r.Records(),
+			totalRequests(r),
+		)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	failing := FailingDatasets(runs)
+	if len(failing) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w, "\nFailing datasets:")
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	for _, f := range failing {
+		fmt.Fprintf(tw, "  %s\tfailed %s since %s\t%s\n", f.Dataset, lastRuns(f.Runs), f.Since.Format(timeFormat), f.LastError)
+	}
+
+	return tw.Flush()
+}
+
+// WriteRun writes the details of a single run
+func WriteRun(w io.Writer, r Run) error {
+	fmt.Fprintf(w, "Run %d\n", r.ID)
+	fmt.Fprintf(w, "Started:   %s\n", r.Start.Format(timeFormat))
+	fmt.Fprintf(w, "Finished:  %s\n", r.End.Format(timeFormat))
+	fmt.Fprintf(w, "Duration:  %s\n", r.Duration().Round(time.Second))
+	fmt.Fprintf(w, "Status:    %s\n", status(r.OK))
+
+	if r.Error != "" {
+		fmt.Fprintf(w, "Error:     %s\n", r.Error)
+	}
+
+	if len(r.Datasets) > 0 {
+		fmt.Fprintln(w)
+
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "DATASET\tSTATUS\tDURATION\tRECORDS")
+
+		for _, d := range r.Datasets {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Name, status(d.OK), d.Duration.Round(time.Second), recordsSummary(d.Records))
+		}
+
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	errors := []string{}
+	for _, d := range r.Datasets {
+		for _, err := range d.Errors {
+			errors = append(errors, fmt.Sprintf("  %s: %s", d.Name, err))
+		}
+	}
+
+	if len(errors) > 0 {
+		fmt.Fprintf(w, "\nErrors:\n%s\n", strings.Join(errors, "\n"))
+	}
+
+	if len(r.Requests) > 0 {
+		fmt.Fprintf(w, "\nRequests:  %s\n", requestsByService(r))
+	}
+
+	return nil
+}
+
+func status(ok bool) string {
+	if ok {
+		return "ok"
+	}
+
+	return "failed"
+}
+
+func datasetsSummary(r Run) string {
+	if len(r.Datasets) == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d/%d ok", len(r.Datasets)-r.FailedDatasets(), len(r.Datasets))
+}
+
+// recordsSummary lists the records written to each sink sorted by sink
+func recordsSummary(records map[string]int) string {
+	if len(records) == 0 {
+		return "-"
+	}
+
+	parts := []string{}
+	for _, sink := range sortedKeys(records) {
+		parts = append(parts, fmt.Sprintf("%s %d", sink, records[sink]))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func totalRequests(r Run) string {
+	total, failed := 0, 0
+	for service, n := range r.Requests {
+		total += n
+		failed += r.RequestErrors[service]
+	}
+
+	return withFailed(total, failed)
+}
+
+// requestsByService lists the requests made to each service sorted by service
+func requestsByService(r Run) string {
+	parts := []string{}
+	for _, service := range sortedKeys(r.Requests) {
+		parts = append(parts, fmt.Sprintf("%s %s", service, withFailed(r.Requests[service], r.RequestErrors[service])))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func withFailed(total, failed int) string {
+	if failed == 0 {
+		return fmt.Sprintf("%d", total)
+	}
+
+	return fmt.Sprintf("%d (%d failed)", total, failed)
+}
+
+func lastRuns(n int) string {
+	if n == 1 {
+		return "the last run"
+	}
+
+	return fmt.Sprintf("the last %d runs", n)
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,95 @@
+package history
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestWriteRuns(t *testing.T) {
+	start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	runs := []Run{
+		{
+			ID:    1,
+			Start: start,
+			End:   start.Add(65 * time.Second),
+			OK:    true,
+			Datasets: []DatasetRun{
+				{Name: "bullhorn-contacts", OK: true, Records: map[string]int{"geckoboard": 10, "csv": 10}},
+				{Name: "bullhorn-placements", OK: true, Records: map[string]int{"geckoboard": 5}},
+			},
+			Requests: map[string]int{"bullhorn": 4, "geckoboard": 3},
+		},
+		{
+			ID:    2,
+			Start: start.Add(15 * time.Minute),
+			End:   start.Add(16 * time.Minute),
+			Datasets: []DatasetRun{
+				{Name: "bullhorn-contacts", OK: true, Records: map[string]int{"geckoboard": 2}},
+				{Name: "bullhorn-placements", Errors: []string{"pushing to geckoboard: bad gateway"}},
+			},
+			Requests:      map[string]int{"bullhorn": 4, "geckoboard": 3},
+			RequestErrors: map[string]int{"geckoboard": 1},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NilError(t, WriteRuns(buf, runs, 0))
+	assert.Equal(t, buf.String(), ""+
+		"ID  STARTED              DURATION  STATUS  DATASETS  RECORDS  REQUESTS\n"+
+		"2   2022-06-01 10:15:00  1m0s      failed  1/2 ok    2        7 (1 failed)\n"+
+		"1   2022-06-01 10:00:00  1m5s      ok      2/2 ok    25       7\n"+
+		"\n"+
+		"Failing datasets:\n"+
+		"  bullhorn-placements  failed the last run since 2022-06-01 10:15:00  pushing to geckoboard: bad gateway\n")
+
+	buf.Reset()
+	assert.NilError(t, WriteRuns(buf, []Run{runs[0], runs[1], runs[1]}, 1))
+	assert.Equal(t, buf.String(), ""+
+		"ID  STARTED              DURATION  STATUS  DATASETS  RECORDS  REQUESTS\n"+
+		"2   2022-06-01 10:15:00  1m0s      failed  1/2 ok    2        7 (1 failed)\n"+
+		"\n"+
+		"Failing datasets:\n"+
+		"  bullhorn-placements  failed the last 2 runs since 2022-06-01 10:15:00  pushing to geckoboard: bad gateway\n")
+
+	buf.Reset()
+	assert.NilError(t, WriteRuns(buf, nil, 0))
+	assert.Equal(t, buf.String(), "No runs yet\n")
+}
+
+func TestWriteRun(t *testing.T) {
+	start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	run := Run{
+		ID:    2,
+		Start: start,
+		End:   start.Add(time.Minute),
+		Datasets: []DatasetRun{
+			{Name: "bullhorn-contacts", OK: true, Duration: 20 * time.Second, Records: map[string]int{"geckoboard": 2, "csv": 2}},
+			{Name: "bullhorn-placements", Duration: 3 * time.Second, Errors: []string{"pushing to geckoboard: bad gateway"}},
+		},
+		Requests:      map[string]int{"bullhorn": 4, "geckoboard": 3},
+		RequestErrors: map[string]int{"geckoboard": 1},
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NilError(t, WriteRun(buf, run))
+	assert.Equal(t, buf.String(), ""+
+		"Run 2\n"+
+		"Started:   2022-06-01 10:00:00\n"+
+		"Finished:  2022-06-01 10:01:00\n"+
+		"Duration:  1m0s\n"+
+		"Status:    failed\n"+
+		"\n"+
+		"DATASET              STATUS  DURATION  RECORDS\n"+
+		"bullhorn-contacts    ok      20s       csv 2, geckoboard 2\n"+
+		"bullhorn-placements  failed  3s        -\n"+
+		"\n"+
+		"Errors:\n"+
+		"  bullhorn-placements: pushing to geckoboard: bad gateway\n"+
+		"\n"+
+		"Requests:  bullhorn 4, geckoboard 3 (1 failed)\n")
+}
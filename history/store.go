@@ -0,0 +1,148 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileStore keeps the runs in a file, one JSON line per run appended after
+// each run. Runs older than the retention are dropped when appending
+type FileStore struct {
+	path string
+	// retention is how long runs are kept for, forever when it's zero
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewFileStore creates the directory of the history file when it doesn't exist
+func NewFileStore(path string, retention time.Duration) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileStore{path: path, retention: retention, now: time.Now}, nil
+}
+
+// Load returns the runs oldest first, none when there is no history file.
+// A last line which doesn't parse is left out as it would be from a run
+// stopped part way through appending it
+func (s *FileStore) Load() ([]Run, error) {
+	runs, _, err := s.load()
+	return runs, err
+}
+
+// load is Load also returning whether a partly written last line was left out
+func (s *FileStore) load() ([]Run, bool, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Run{}, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	runs := []Run{}
+	lines := bytes.Split(bytes.TrimRight(b, "\n"), []byte("\n"))
+
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		run := Run{}
+		if err := json.Unmarshal(line, &run); err != nil {
+			if i == len(lines)-1 {
+				return runs, true, nil
+			}
+
+			return nil, false, fmt.Errorf("reading run history line %d: %w", i+1, err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs, false, nil
+}
+
+// Append adds the run to the history with the next ID, which it returns
+// the run with. When some runs are past the retention, or the last run
+// was only partly written, the file is written again without them
+func (s *FileStore) Append(run Run) (Run, error) {
+	runs, torn, err := s.load()
+	if err != nil {
+		return run, err
+	}
+
+	run.ID = 1
+	if len(runs) > 0 {
+		run.ID = runs[len(runs)-1].ID + 1
+	}
+
+	kept := s.retained(runs)
+	if torn || len(kept) < len(runs) {
+		return run, s.write(append(kept, run))
+	}
+
+	line, err := json.Marshal(run)
+	if err != nil {
+		return run, err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return run, err
+	}
+
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return run, err
+}
+
+// retained returns the runs which started within the retention
+func (s *FileStore) retained(runs []Run) []Run {
+	if s.retention <= 0 {
+		return runs
+	}
+
+	cutoff := s.now().Add(-s.retention)
+	kept := []Run{}
+
+	for _, r := range runs {
+		if !r.Start.Before(cutoff) {
+			kept = append(kept, r)
+		}
+	}
+
+	return kept
+}
+
+// write replaces the history file with the runs, writing to a temporary file
+// first so the history isn't lost if the run is stopped part way through
+func (s *FileStore) write(runs []Run) error {
+	buf := &bytes.Buffer{}
+	for _, r := range runs {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
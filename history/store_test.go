@@ -0,0 +1,98 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	now := time.Date(2022, 6, 10, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "state", "history.jsonl")
+
+	store, err := NewFileStore(path, 7*24*time.Hour)
+	assert.NilError(t, err)
+	store.now = func() time.Time { return now }
+
+	t.Run("returns no runs without a history file", func(t *testing.T) {
+		runs, err := store.Load()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, runs, []Run{})
+	})
+
+	t.Run("appends runs with increasing IDs", func(t *testing.T) {
+		for _, start := range []time.Time{now.AddDate(0, 0, -6), now.AddDate(0, 0, -1)} {
+			_, err := store.Append(Run{
+				Start:    start,
+				End:      start.Add(time.Minute),
+				OK:       true,
+				Datasets: []DatasetRun{{Name: "bullhorn-contacts", OK: true, Records: map[string]int{"geckoboard": 3}}},
+			})
+			assert.NilError(t, err)
+		}
+
+		runs, err := store.Load()
+		assert.NilError(t, err)
+		assert.Equal(t, len(runs), 2)
+		assert.Equal(t, runs[0].ID, 1)
+		assert.Equal(t, runs[1].ID, 2)
+		assert.Equal(t, runs[1].Datasets[0].Records["geckoboard"], 3)
+	})
+
+	t.Run("drops runs past the retention when appending", func(t *testing.T) {
+		now = now.AddDate(0, 0, 2)
+
+		run, err := store.Append(Run{Start: now, End: now.Add(time.Minute)})
+		assert.NilError(t, err)
+		assert.Equal(t, run.ID, 3)
+
+		runs, err := store.Load()
+		assert.NilError(t, err)
+		assert.Equal(t, len(runs), 2)
+		assert.Equal(t, runs[0].ID, 2)
+		assert.Equal(t, runs[1].ID, 3)
+
+		_, err = os.Stat(path + ".tmp")
+		assert.Assert(t, os.IsNotExist(err))
+	})
+
+	t.Run("leaves out a partly written last run", func(t *testing.T) {
+		info, err := os.Stat(path)
+		assert.NilError(t, err)
+		assert.NilError(t, os.Truncate(path, info.Size()-10))
+
+		runs, err := store.Load()
+		assert.NilError(t, err)
+		assert.Equal(t, len(runs), 1)
+	})
+
+	t.Run("drops a partly written last run when appending", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := store.Append(Run{Start: now, End: now.Add(time.Minute)})
+			assert.NilError(t, err)
+		}
+
+		runs, err := store.Load()
+		assert.NilError(t, err)
+		assert.Equal(t, len(runs), 3)
+		assert.Equal(t, runs[1].ID, 3)
+		assert.Equal(t, runs[2].ID, 4)
+
+		b, err := os.ReadFile(path)
+		assert.NilError(t, err)
+		assert.Equal(t, len(strings.Split(strings.TrimSpace(string(b)), "\n")), 3)
+	})
+
+	t.Run("returns error when an earlier run is corrupt", func(t *testing.T) {
+		b, err := os.ReadFile(path)
+		assert.NilError(t, err)
+		assert.NilError(t, os.WriteFile(path, []byte("{\n"+strings.SplitN(string(b), "\n", 2)[0]+"\n"), 0o644))
+
+		_, err = store.Load()
+		assert.ErrorContains(t, err, "reading run history line 1")
+	})
+}
@@ -0,0 +1,182 @@
+package lock
+
+import (
+	"bullhorn-to-dataset/shutdown"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pollInterval is how often a held lock is checked while waiting for it
+var pollInterval = time.Second
+
+// HeldError is returned when another process still holds the lock
+type HeldError struct {
+	Path string
+	PID  int
+}
+
+func (e *HeldError) Error() string {
+	if e.PID == 0 {
+		return fmt.Sprintf("lock %s is held by another process", e.Path)
+	}
+
+	return fmt.Sprintf("lock %s is held by process %d", e.Path, e.PID)
+}
+
+// staleTakeOver is how old a takeover file has to be before it's
+// assumed to be left by a process which exited while taking over
+const staleTakeOver = 10 * time.Second
+
+// Lock is a pid file held by this process, so runs on the same machine don't overlap
+type Lock struct {
+	path string
+}
+
+// Acquire creates the lock file holding the pid of this process. When another
+// process holds it, it's checked again until the wait has passed before a
+// HeldError is returned. A lock left by a process which has since exited
+// is stale and is taken over
+func Acquire(ctx context.Context, path string, wait time.Duration) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating lock directory: %w", err)
+	}
+
+	deadline := time.Now().Add(wait)
+
+	for {
+		err := create(path)
+		if err == nil {
+			return &Lock{path: path}, nil
+		}
+
+		var held *HeldError
+		if !errors.As(err, &held) || !time.Now().Before(deadline) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-shutdown.Stopping(ctx):
+			return nil, shutdown.ErrStopping
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Release removes the lock file
+func (l *Lock) Release() error {
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing lock %s: %w", l.path, err)
+	}
+
+	return nil
+}
+
+// create writes the lock file unless it exists, removing it first when it's stale
+func create(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if os.IsExist(err) {
+		pid, alive := holder(path)
+		if alive {
+			return &HeldError{Path: path, PID: pid}
+		}
+
+		if err := takeOver(path, pid); err != nil {
+			return err
+		}
+
+		f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if os.IsExist(err) {
+			// Another process took the lock once the stale one was removed
+			pid, _ := holder(path)
+			return &HeldError{Path: path, PID: pid}
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("creating lock %s: %w", path, err)
+	}
+
+	_, err = fmt.Fprintf(f, "%d\n", os.Getpid())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("writing lock %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// takeOver removes the stale lock left by the process with the pid. Only one
+// process takes over at a time, holding the takeover file while it checks the
+// lock still has the stale pid right before removing it, so a lock another
+// process has just taken is never removed
+func takeOver(path string, stalePID int) error {
+	guard := path + ".takeover"
+
+	g, err := os.OpenFile(guard, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if os.IsExist(err) {
+		if info, statErr := os.Stat(guard); statErr == nil && time.Since(info.ModTime()) > staleTakeOver {
+			// Left by a process which exited while taking over, a new
+			// takeover file can't be stale so this only retries once
+			if err := os.Remove(guard); err == nil || os.IsNotExist(err) {
+				return takeOver(path, stalePID)
+			}
+		}
+
+		return &HeldError{Path: path}
+	}
+
+	if err != nil {
+		return fmt.Errorf("taking over stale lock %s: %w", path, err)
+	}
+
+	g.Close()
+	defer os.Remove(guard)
+
+	pid, alive := holder(path)
+	if alive || pid != stalePID {
+		return &HeldError{Path: path, PID: pid}
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing stale lock %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// holder returns the pid in the lock file and whether that process is still
+// running. A lock with this process's own pid is stale as it would have been
+// left by an earlier process with the same pid, such as pid 1 in a container
+func holder(path string) (int, bool) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		// Removed by its holder since it was found, so it's free now
+		return 0, false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || pid <= 0 {
+		// Creating the lock may not have finished writing the pid yet,
+		// it's only stale once it's old enough for that to be unlikely
+		info, statErr := os.Stat(path)
+		return 0, statErr == nil && time.Since(info.ModTime()) < time.Minute
+	}
+
+	if pid == os.Getpid() {
+		return pid, false
+	}
+
+	return pid, processExists(pid)
+}
@@ -0,0 +1,151 @@
+package lock
+
+import (
+	"bullhorn-to-dataset/shutdown"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAcquire(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	ctx := context.Background()
+
+	t.Run("creates and releases the lock", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state", "push.lock")
+
+		l, err := Acquire(ctx, path, 0)
+		assert.NilError(t, err)
+
+		b, err := os.ReadFile(path)
+		assert.NilError(t, err)
+		assert.Equal(t, string(b), strconv.Itoa(os.Getpid())+"\n")
+
+		assert.NilError(t, l.Release())
+		_, err = os.Stat(path)
+		assert.Assert(t, os.IsNotExist(err))
+	})
+
+	t.Run("returns error when another process holds the lock", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "push.lock")
+		writeLock(t, path, os.Getppid())
+
+		_, err := Acquire(ctx, path, 0)
+
+		var held *HeldError
+		assert.Assert(t, errors.As(err, &held))
+		assert.Equal(t, held.PID, os.Getppid())
+		assert.Error(t, err, "lock "+path+" is held by process "+strconv.Itoa(os.Getppid()))
+	})
+
+	t.Run("takes over a stale lock", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "push.lock")
+		writeLock(t, path, exitedPID(t))
+
+		l, err := Acquire(ctx, path, 0)
+		assert.NilError(t, err)
+		assert.NilError(t, l.Release())
+
+		writeLock(t, path, os.Getpid())
+		l, err = Acquire(ctx, path, 0)
+		assert.NilError(t, err)
+		assert.NilError(t, l.Release())
+	})
+
+	t.Run("doesn't take over while another process is taking over", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "push.lock")
+		writeLock(t, path, exitedPID(t))
+		assert.NilError(t, os.WriteFile(path+".takeover", nil, 0o644))
+
+		_, err := Acquire(ctx, path, 0)
+		assert.Error(t, err, "lock "+path+" is held by another process")
+
+		// Left by a process which exited while taking over
+		old := time.Now().Add(-time.Minute)
+		assert.NilError(t, os.Chtimes(path+".takeover", old, old))
+
+		l, err := Acquire(ctx, path, 0)
+		assert.NilError(t, err)
+		assert.NilError(t, l.Release())
+	})
+
+	t.Run("doesn't remove a lock taken since it was found stale", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "push.lock")
+		stale := exitedPID(t)
+
+		// Another process removed the stale lock and took it after it was read
+		writeLock(t, path, os.Getppid())
+
+		err := takeOver(path, stale)
+
+		var held *HeldError
+		assert.Assert(t, errors.As(err, &held))
+		assert.Equal(t, held.PID, os.Getppid())
+
+		b, err := os.ReadFile(path)
+		assert.NilError(t, err)
+		assert.Equal(t, string(b), strconv.Itoa(os.Getppid())+"\n")
+
+		_, err = os.Stat(path + ".takeover")
+		assert.Assert(t, os.IsNotExist(err))
+	})
+
+	t.Run("waits for the lock to be released", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "push.lock")
+		writeLock(t, path, os.Getppid())
+
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			os.Remove(path)
+		}()
+
+		l, err := Acquire(ctx, path, 10*time.Second)
+		assert.NilError(t, err)
+		assert.NilError(t, l.Release())
+	})
+
+	t.Run("gives up waiting after the wait", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "push.lock")
+		writeLock(t, path, os.Getppid())
+
+		start := time.Now()
+		_, err := Acquire(ctx, path, 50*time.Millisecond)
+
+		var held *HeldError
+		assert.Assert(t, errors.As(err, &held))
+		assert.Assert(t, time.Since(start) >= 50*time.Millisecond)
+	})
+
+	t.Run("stops waiting when shutting down", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "push.lock")
+		writeLock(t, path, os.Getppid())
+
+		ctx, stop := shutdown.WithStop(ctx)
+		stop()
+
+		_, err := Acquire(ctx, path, time.Hour)
+		assert.ErrorIs(t, err, shutdown.ErrStopping)
+	})
+}
+
+func writeLock(t *testing.T, path string, pid int) {
+	t.Helper()
+	assert.NilError(t, os.WriteFile(path, []byte(strconv.Itoa(pid)+"\n"), 0o644))
+}
+
+// exitedPID returns the pid of a process which has exited
+func exitedPID(t *testing.T) int {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	assert.NilError(t, cmd.Run())
+
+	return cmd.Process.Pid
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package lock
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processExists sends the process the null signal, which only checks it can be signalled
+func processExists(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,17 @@
+//go:build windows
+// +build windows
+
+package lock
+
+import "os"
+
+// processExists opens the process, which fails on windows when there is none
+func processExists(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	p.Release()
+	return true
+}
@@ -0,0 +1,270 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const namespace = "bullhorn_to_dataset"
+
+type datasetMetrics struct {
+	recordsPushed map[string]int
+	duration      time.Duration
+	lastSuccess   time.Time
+	failures      int
+}
+
+type requestKey struct {
+	service string
+	code    string
+}
+
+// Registry keeps the metrics of the pushes and requests made
+// since the app started, it's safe to use concurrently
+type Registry struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	startedAt   time.Time
+	lastCycle   time.Time
+	lastSuccess time.Time
+	cycles      int
+	stopping    bool
+
+	datasets map[string]*datasetMetrics
+	requests map[requestKey]int
+	errors   map[requestKey]int
+}
+
+func NewRegistry() *Registry {
+	return newRegistry(time.Now)
+}
+
+func newRegistry(now func() time.Time) *Registry {
+	return &Registry{
+		now:       now,
+		startedAt: now(),
+		datasets:  map[string]*datasetMetrics{},
+		requests:  map[requestKey]int{},
+		errors:    map[requestKey]int{},
+	}
+}
+
+func (r *Registry) dataset(name string) *datasetMetrics {
+	d, ok := r.datasets[name]
+	if !ok {
+		d = &datasetMetrics{recordsPushed: map[string]int{}}
+		r.datasets[name] = d
+	}
+
+	return d
+}
+
+// RecordWrite adds the records written to a sink for the dataset
+func (r *Registry) RecordWrite(dataset, sink string, records int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dataset(dataset).recordsPushed[sink] += records
+}
+
+// RecordDataset records how long pushing the dataset took and whether it succeeded
+func (r *Registry) RecordDataset(dataset string, duration time.Duration, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.dataset(dataset)
+	d.duration = duration
+
+	if ok {
+		d.lastSuccess = r.now()
+	} else {
+		d.failures++
+	}
+}
+
+// RecordCycle records the end of a run pushing one or more datasets,
+// the app is ready once the first one has finished
+func (r *Registry) RecordCycle(ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cycles++
+	r.lastCycle = r.now()
+
+	if ok {
+		r.lastSuccess = r.lastCycle
+	}
+}
+
+// SetStopping marks the app as no longer ready as it's shutting down
+func (r *Registry) SetStopping() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopping = true
+}
+
+// RecordRequest counts a request to the service by its status code, a code
+// of zero is a request which failed without a response
+func (r *Registry) RecordRequest(service string, code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := requestKey{service: service, code: strconv.Itoa(code)}
+	if code == 0 {
+		key.code = "error"
+	}
+
+	r.requests[key]++
+	if code == 0 || code >= http.StatusBadRequest {
+		r.errors[key]++
+	}
+}
+
+// Healthy returns an error saying why when the last successful run is
+// older than the window, before the first run the window starts at start up
+func (r *Registry) Healthy(window time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := r.lastSuccess
+	if last.IsZero() {
+		if r.cycles > 0 {
+			return fmt.Errorf("no run has succeeded since starting, the last finished at %s", r.lastCycle.Format(time.RFC3339))
+		}
+
+		last = r.startedAt
+	}
+
+	if since := r.now().Sub(last); since > window {
+		return fmt.Errorf("last successful run was %s ago, more than %s", since.Round(time.Second), window)
+	}
+
+	return nil
+}
+
+// Ready is true once the first run has finished until the app starts shutting down
+func (r *Registry) Ready() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cycles > 0 && !r.stopping
+}
+
+// WriteTo writes the metrics in the Prometheus text format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := &strings.Builder{}
+	names := datasetNames(r.datasets)
+
+	writeHeader(b, "records_pushed_total", "counter", "Records written to each sink by dataset.")
+	for _, name := range names {
+		d := r.datasets[name]
+		for _, sink := range sinkNames(d.recordsPushed) {
+			writeSample(b, "records_pushed_total", labels("dataset", name, "sink", sink), float64(d.recordsPushed[sink]))
+		}
+	}
+
+	writeHeader(b, "push_duration_seconds", "gauge", "How long the last push of each dataset took.")
+	for _, name := range names {
+		writeSample(b, "push_duration_seconds", labels("dataset", name), r.datasets[name].duration.Seconds())
+	}
+
+	writeHeader(b, "push_failures_total", "counter", "Pushes of each dataset which failed.")
+	for _, name := range names {
+		writeSample(b, "push_failures_total", labels("dataset", name), float64(r.datasets[name].failures))
+	}
+
+	writeHeader(b, "last_success_timestamp_seconds", "gauge", "Unix time each dataset was last pushed successfully.")
+	for _, name := range names {
+		writeSample(b, "last_success_timestamp_seconds", labels("dataset", name), unixSeconds(r.datasets[name].lastSuccess))
+	}
+
+	writeHeader(b, "last_run_success_timestamp_seconds", "gauge", "Unix time of the last run where every dataset was pushed.")
+	writeSample(b, "last_run_success_timestamp_seconds", "", unixSeconds(r.lastSuccess))
+
+	writeHeader(b, "http_requests_total", "counter", "Requests made to each service by status code.")
+	writeRequests(b, "http_requests_total", r.requests)
+
+	writeHeader(b, "http_request_errors_total", "counter", "Requests to each service which failed by status code.")
+	writeRequests(b, "http_request_errors_total", r.errors)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func writeHeader(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s_%s %s\n", namespace, name, help)
+	fmt.Fprintf(b, "# TYPE %s_%s %s\n", namespace, name, kind)
+}
+
+func writeSample(b *strings.Builder, name, labels string, v float64) {
+	fmt.Fprintf(b, "%s_%s%s %s\n", namespace, name, labels, strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+func writeRequests(b *strings.Builder, name string, counts map[requestKey]int) {
+	keys := []requestKey{}
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].service != keys[j].service {
+			return keys[i].service < keys[j].service
+		}
+
+		return keys[i].code < keys[j].code
+	})
+
+	for _, k := range keys {
+		writeSample(b, name, labels("service", k.service, "code", k.code), float64(counts[k]))
+	}
+}
+
+// labels formats pairs of label names and values, escaping the values
+func labels(pairs ...string) string {
+	parts := []string{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		v := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(pairs[i+1])
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, pairs[i], v))
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func unixSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+func datasetNames(m map[string]*datasetMetrics) []string {
+	names := []string{}
+	for k := range m {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func sinkNames(m map[string]int) []string {
+	names := []string{}
+	for k := range m {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+	return names
+}
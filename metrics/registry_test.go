@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	r := newRegistry(func() time.Time { return now })
+
+	r.RecordWrite("bullhorn-placements", "geckoboard", 20)
+	r.RecordWrite("bullhorn-placements", "geckoboard", 5)
+	r.RecordWrite("bullhorn-placements", "csv", 25)
+	r.RecordDataset("bullhorn-placements", 1500*time.Millisecond, true)
+	r.RecordDataset("bullhorn-contacts", 2*time.Second, false)
+	r.RecordCycle(true)
+
+	r.RecordRequest("geckoboard", 200)
+	r.RecordRequest("geckoboard", 200)
+	r.RecordRequest("geckoboard", 400)
+	r.RecordRequest("bullhorn", 0)
+
+	buf := &bytes.Buffer{}
+	_, err := r.WriteTo(buf)
+	assert.NilError(t, err)
+
+	assert.Equal(t, buf.String(), `# HELP bullhorn_to_dataset_records_pushed_total Records written to each sink by dataset.
+# TYPE bullhorn_to_dataset_records_pushed_total counter
+bullhorn_to_dataset_records_pushed_total{dataset="bullhorn-placements",sink="csv"} 25
+bullhorn_to_dataset_records_pushed_total{dataset="bullhorn-placements",sink="geckoboard"} 25
+# HELP bullhorn_to_dataset_push_duration_seconds How long the last push of each dataset took.
+# TYPE bullhorn_to_dataset_push_duration_seconds gauge
+bullhorn_to_dataset_push_duration_seconds{dataset="bullhorn-contacts"} 2
+bullhorn_to_dataset_push_duration_seconds{dataset="bullhorn-placements"} 1.5
+# HELP bullhorn_to_dataset_push_failures_total Pushes of each dataset which failed.
+# TYPE bullhorn_to_dataset_push_failures_total counter
+bullhorn_to_dataset_push_failures_total{dataset="bullhorn-contacts"} 1
+bullhorn_to_dataset_push_failures_total{dataset="bullhorn-placements"} 0
+# HELP bullhorn_to_dataset_last_success_timestamp_seconds Unix time each dataset was last pushed successfully.
+# TYPE bullhorn_to_dataset_last_success_timestamp_seconds gauge
+bullhorn_to_dataset_last_success_timestamp_seconds{dataset="bullhorn-contacts"} 0
+bullhorn_to_dataset_last_success_timestamp_seconds{dataset="bullhorn-placements"} 1654077600
+# HELP bullhorn_to_dataset_last_run_success_timestamp_seconds Unix time of the last run where every dataset was pushed.
+# TYPE bullhorn_to_dataset_last_run_success_timestamp_seconds gauge
+bullhorn_to_dataset_last_run_success_timestamp_seconds 1654077600
+# HELP bullhorn_to_dataset_http_requests_total Requests made to each service by status code.
+# TYPE bullhorn_to_dataset_http_requests_total counter
+bullhorn_to_dataset_http_requests_total{service="bullhorn",code="error"} 1
+bullhorn_to_dataset_http_requests_total{service="geckoboard",code="200"} 2
+bullhorn_to_dataset_http_requests_total{service="geckoboard",code="400"} 1
+# HELP bullhorn_to_dataset_http_request_errors_total Requests to each service which failed by status code.
+# TYPE bullhorn_to_dataset_http_request_errors_total counter
+bullhorn_to_dataset_http_request_errors_total{service="bullhorn",code="error"} 1
+bullhorn_to_dataset_http_request_errors_total{service="geckoboard",code="400"} 1
+`)
+}
+
+func TestRegistry_Healthy(t *testing.T) {
+	start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := start
+	r := newRegistry(func() time.Time { return now })
+
+	t.Run("is healthy while starting up", func(t *testing.T) {
+		now = start.Add(10 * time.Minute)
+		assert.NilError(t, r.Healthy(time.Hour))
+		assert.Assert(t, !r.Ready())
+	})
+
+	t.Run("is unhealthy when no run has succeeded", func(t *testing.T) {
+		r.RecordCycle(false)
+		assert.Error(t, r.Healthy(time.Hour), "no run has succeeded since starting, the last finished at 2022-06-01T10:10:00Z")
+		assert.Assert(t, r.Ready())
+	})
+
+	t.Run("is healthy within the window of the last successful run", func(t *testing.T) {
+		r.RecordCycle(true)
+		now = now.Add(time.Hour)
+		assert.NilError(t, r.Healthy(time.Hour))
+	})
+
+	t.Run("is unhealthy after the window", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		assert.Error(t, r.Healthy(time.Hour), "last successful run was 1h1m0s ago, more than 1h0m0s")
+	})
+
+	t.Run("isn't ready once stopping", func(t *testing.T) {
+		r.SetStopping()
+		assert.Assert(t, !r.Ready())
+	})
+}
+
+func TestLabels(t *testing.T) {
+	assert.Equal(t, labels("dataset", `a"b\c`+"\n"), `{dataset="a\"b\\c\n"}`)
+}
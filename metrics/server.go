@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"bullhorn-to-dataset/shutdown"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Handler serves /healthz, /readyz and /metrics. The app is healthy while
+// the last successful run was within the window. More routes can be added
+// to the returned mux
+func (r *Registry) Handler(window time.Duration) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		if err := r.Healthy(window); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !r.Ready() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteTo(w)
+	})
+
+	return mux
+}
+
+// Serve listens on the address with the handler, usually the registry's own,
+// until the app starts shutting down, when the app stops being ready. It returns
+// once the listener is open so an address which can't be listened on is
+// reported straight away
+func (r *Registry) Serve(ctx context.Context, addr string, handler http.Handler) (net.Addr, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-shutdown.Stopping(ctx)
+		r.SetStopping()
+
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	go func() {
+		if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("Metrics server failed with error: %s\n", err)
+		}
+	}()
+
+	return l.Addr(), nil
+}
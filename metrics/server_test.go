@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRegistry_Handler(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	r := newRegistry(func() time.Time { return now })
+
+	server := httptest.NewServer(r.Handler(time.Hour))
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(server.URL + path)
+		assert.NilError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		assert.NilError(t, err)
+
+		return resp.StatusCode, string(b)
+	}
+
+	code, _ := get("/readyz")
+	assert.Equal(t, code, http.StatusServiceUnavailable)
+
+	r.RecordCycle(true)
+
+	code, body := get("/readyz")
+	assert.Equal(t, code, http.StatusOK)
+	assert.Equal(t, body, "ok\n")
+
+	code, _ = get("/healthz")
+	assert.Equal(t, code, http.StatusOK)
+
+	now = now.Add(2 * time.Hour)
+	code, body = get("/healthz")
+	assert.Equal(t, code, http.StatusServiceUnavailable)
+	assert.Equal(t, body, "last successful run was 2h0m0s ago, more than 1h0m0s\n")
+
+	code, body = get("/metrics")
+	assert.Equal(t, code, http.StatusOK)
+	assert.Assert(t, strings.Contains(body, "bullhorn_to_dataset_last_run_success_timestamp_seconds 1654077600\n"))
+}
+
+func TestRegistry_Transport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := NewRegistry()
+	client := &http.Client{Transport: r.Transport("bullhorn", nil)}
+
+	for _, path := range []string{"/", "/", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		assert.NilError(t, err)
+		resp.Body.Close()
+	}
+
+	_, err := client.Get("http://127.0.0.1:0/")
+	assert.Assert(t, err != nil)
+
+	assert.DeepEqual(t, r.requests, map[requestKey]int{
+		{service: "bullhorn", code: "200"}:   2,
+		{service: "bullhorn", code: "404"}:   1,
+		{service: "bullhorn", code: "error"}: 1,
+	})
+	assert.DeepEqual(t, r.errors, map[requestKey]int{
+		{service: "bullhorn", code: "404"}:   1,
+		{service: "bullhorn", code: "error"}: 1,
+	})
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import "net/http"
+
+type transport struct {
+	service  string
+	next     http.RoundTripper
+	registry *Registry
+}
+
+// Transport counts the requests sent through it for the service by status
+// code, sending them on with next or the default transport when it's nil
+func (r *Registry) Transport(service string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{service: service, next: next, registry: r}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.registry.RecordRequest(t.service, 0)
+		return nil, err
+	}
+
+	t.registry.RecordRequest(t.service, resp.StatusCode)
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// chatPayload is the body incoming webhooks of both Slack
+// and Microsoft Teams accept as a plain text message
+type chatPayload struct {
+	Text string `json:"text"`
+}
+
+// Chat posts alerts to a Slack or Teams style incoming webhook
+type Chat struct {
+	url    string
+	client *http.Client
+}
+
+func NewChat(webhookURL string) (*Chat, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid chat webhook url %q, expected an http or https url", webhookURL)
+	}
+
+	return &Chat{url: webhookURL, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// String leaves out the rest of the url as incoming webhook urls hold their secret
+func (c *Chat) String() string {
+	u, _ := url.Parse(c.url)
+	return "chat webhook " + u.Host
+}
+
+func (c *Chat) Send(ctx context.Context, msg Message) error {
+	b, err := json.Marshal(chatPayload{Text: fmt.Sprintf("*%s*\n%s", msg.Subject, msg.Body)})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("chat webhook responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestChat_Send(t *testing.T) {
+	var got chatPayload
+	status := http.StatusOK
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, req.Method, http.MethodPost)
+		assert.Equal(t, req.Header.Get("Content-Type"), "application/json")
+		assert.NilError(t, json.NewDecoder(req.Body).Decode(&got))
+
+		w.WriteHeader(status)
+		w.Write([]byte("invalid_token"))
+	}))
+	defer server.Close()
+
+	c, err := NewChat(server.URL + "/services/T000/B000/XXXX")
+	assert.NilError(t, err)
+	assert.Equal(t, c.String(), "chat webhook "+server.Listener.Addr().String())
+
+	err = c.Send(context.Background(), Message{Subject: "1 dataset failing", Body: "contacts failed\n"})
+	assert.NilError(t, err)
+	assert.Equal(t, got.Text, "*1 dataset failing*\ncontacts failed\n")
+
+	status = http.StatusForbidden
+	err = c.Send(context.Background(), Message{Subject: "1 dataset failing"})
+	assert.Error(t, err, "chat webhook responded with status 403: invalid_token")
+}
+
+func TestNewChat(t *testing.T) {
+	_, err := NewChat("hooks.slack.com/services/T000")
+	assert.Error(t, err, `invalid chat webhook url "hooks.slack.com/services/T000", expected an http or https url`)
+}
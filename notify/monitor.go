@@ -0,0 +1,273 @@
+package notify
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/printer"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is an alert about datasets failing or recovering
+type Message struct {
+	Subject string
+	Body    string
+}
+
+// Notifier sends alerts somewhere people will see them
+type Notifier interface {
+	fmt.Stringer
+
+	Send(context.Context, Message) error
+}
+
+// loginCheck is what failing to log in to Bullhorn is alerted about as,
+// as none of the datasets are pushed then
+const loginCheck = "Logging in to Bullhorn"
+
+// stateFile keeps the failures in a row in the state dir between runs
+const stateFile = "alerts.json"
+
+// datasetState is how a dataset has been doing over the recent runs
+type datasetState struct {
+	Failures int `json:"failures"`
+	// FailingSince is when the first of the failed runs in a row was
+	FailingSince time.Time `json:"failing_since"`
+	// Alerted is when the last alert about the dataset was sent, zero when there wasn't one
+	Alerted   time.Time `json:"alerted"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+// Monitor counts the runs each dataset fails in a row and alerts once the
+// threshold is reached. Datasets which are still failing are only alerted
+// about again after the repeat interval, never when it's zero, and once
+// an alerted dataset succeeds a recovery notice is sent.
+// It records the pushes of a processor, sending at most one alert per cycle.
+// The failures are only counted across runs of a single process unless
+// they're kept in a state dir
+type Monitor struct {
+	notifiers []Notifier
+	threshold int
+	repeat    time.Duration
+	printer   printer.Printer
+	now       func() time.Time
+	// statePath is only set when the state is kept between runs
+	statePath string
+
+	mu        sync.Mutex
+	datasets  map[string]*datasetState
+	failing   []string
+	recovered []string
+}
+
+func NewMonitor(notifiers []Notifier, threshold int, repeat time.Duration, p printer.Printer) *Monitor {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &Monitor{
+		notifiers: notifiers,
+		threshold: threshold,
+		repeat:    repeat,
+		printer:   p,
+		now:       time.Now,
+		datasets:  map[string]*datasetState{},
+	}
+}
+
+// KeepStateIn loads the failures counted by earlier runs from the state dir,
+// saving them there after each cycle so runs with --single-run can alert too
+func (m *Monitor) KeepStateIn(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.statePath = filepath.Join(dir, stateFile)
+
+	b, err := os.ReadFile(m.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, &m.datasets); err != nil {
+		return fmt.Errorf("reading alert state: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Monitor) RecordWrite(string, string, int) {}
+
+// RecordError keeps the error to include in the alert about the dataset
+func (m *Monitor) RecordError(dataset string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state(dataset).LastError = bullhorn.RedactedError(err)
+}
+
+// RecordLogin counts failing to log in to Bullhorn like a dataset failing.
+// As the run ends when it fails the cycle is finished straight away,
+// sending any alert which is due
+func (m *Monitor) RecordLogin(err error) {
+	if err == nil {
+		m.RecordDataset(loginCheck, 0, true)
+		return
+	}
+
+	m.RecordError(loginCheck, err)
+	m.RecordDataset(loginCheck, 0, false)
+	m.RecordCycle(false)
+}
+
+// state returns the state of the dataset, adding it the first time it's seen
+func (m *Monitor) state(dataset string) *datasetState {
+	state, found := m.datasets[dataset]
+	if !found {
+		state = &datasetState{}
+		m.datasets[dataset] = state
+	}
+
+	return state
+}
+
+// RecordDataset counts the failures in a row of the dataset,
+// queuing an alert when one is due
+func (m *Monitor) RecordDataset(dataset string, _ time.Duration, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := m.state(dataset)
+	now := m.now()
+
+	if ok {
+		if !state.Alerted.IsZero() {
+			m.recovered = append(m.recovered, fmt.Sprintf("%s has recovered after failing %d runs in a row", dataset, state.Failures))
+		}
+
+		delete(m.datasets, dataset)
+		return
+	}
+
+	if state.Failures == 0 {
+		state.FailingSince = now
+	}
+
+	state.Failures++
+	if state.Failures < m.threshold {
+		return
+	}
+
+	firstAlert := state.Alerted.IsZero()
+	repeatDue := m.repeat > 0 && now.Sub(state.Alerted) >= m.repeat
+	if !firstAlert && !repeatDue {
+		return
+	}
+
+	state.Alerted = now
+
+	line := fmt.Sprintf("%s has failed %d runs in a row, since %s", dataset, state.Failures, state.FailingSince.Format(time.RFC3339))
+	if state.LastError != "" {
+		line += ", last with error: " + state.LastError
+	}
+
+	m.failing = append(m.failing, line)
+}
+
+// RecordCycle sends the alerts queued during the cycle as a single message
+func (m *Monitor) RecordCycle(bool) {
+	m.mu.Lock()
+	msg, ok := m.message()
+	m.failing, m.recovered = nil, nil
+
+	if err := m.saveState(); err != nil {
+		m.printer.Printf("Saving alert state failed with error: %s\n", err)
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	for _, n := range m.notifiers {
+		if err := n.Send(context.Background(), msg); err != nil {
+			m.printer.Printf("Sending alert with %s failed with error: %s\n", n, err)
+		}
+	}
+}
+
+// saveState writes to a temporary file first so a run stopped
+// part way through writing doesn't leave a corrupt state file
+func (m *Monitor) saveState() error {
+	if m.statePath == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(m.datasets)
+	if err != nil {
+		return err
+	}
+
+	tmp := m.statePath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, m.statePath)
+}
+
+// message builds the alert of the datasets failing and recovered
+// in the cycle, ok is false when there's nothing to alert about
+func (m *Monitor) message() (Message, bool) {
+	if len(m.failing) == 0 && len(m.recovered) == 0 {
+		return Message{}, false
+	}
+
+	sort.Strings(m.failing)
+	sort.Strings(m.recovered)
+
+	parts := []string{}
+	if len(m.failing) > 0 {
+		parts = append(parts, fmt.Sprintf("%s failing", plural(len(m.failing), "dataset")))
+	}
+
+	if len(m.recovered) > 0 {
+		parts = append(parts, fmt.Sprintf("%s recovered", plural(len(m.recovered), "dataset")))
+	}
+
+	body := &strings.Builder{}
+	for _, line := range append(m.failing, m.recovered...) {
+		fmt.Fprintln(body, line)
+	}
+
+	if len(m.failing) > 0 {
+		fmt.Fprintln(body, "\nThe logs have the errors each run failed with.")
+	}
+
+	return Message{
+		Subject: "bullhorn-to-dataset: " + strings.Join(parts, ", "),
+		Body:    body.String(),
+	}, true
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
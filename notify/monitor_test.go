@@ -0,0 +1,211 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMonitor(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	sent := &mockNotifier{}
+	logs := &mockLogPrinter{}
+	m := NewMonitor([]Notifier{sent}, 3, 2*time.Hour, logs)
+	m.now = func() time.Time { return now }
+
+	// cycle runs each dataset with the result given, 15 minutes apart
+	cycle := func(results map[string]bool) {
+		for _, d := range []string{"bullhorn-contacts", "bullhorn-placements"} {
+			if ok, found := results[d]; found {
+				m.RecordDataset(d, time.Second, ok)
+			}
+		}
+
+		m.RecordCycle(true)
+		now = now.Add(15 * time.Minute)
+	}
+
+	t.Run("alerts once the threshold is reached", func(t *testing.T) {
+		cycle(map[string]bool{"bullhorn-contacts": false, "bullhorn-placements": true})
+		cycle(map[string]bool{"bullhorn-contacts": false, "bullhorn-placements": false})
+		assert.Equal(t, len(sent.msgs), 0)
+
+		cycle(map[string]bool{"bullhorn-contacts": false, "bullhorn-placements": false})
+		assert.DeepEqual(t, sent.msgs, []Message{{
+			Subject: "bullhorn-to-dataset: 1 dataset failing",
+			Body: "bullhorn-contacts has failed 3 runs in a row, since 2022-06-01T10:00:00Z\n" +
+				"\nThe logs have the errors each run failed with.\n",
+		}})
+
+		cycle(map[string]bool{"bullhorn-contacts": false, "bullhorn-placements": false})
+		assert.Equal(t, len(sent.msgs), 2)
+		assert.Equal(t, sent.msgs[1].Body,
+			"bullhorn-placements has failed 3 runs in a row, since 2022-06-01T10:15:00Z\n"+
+				"\nThe logs have the errors each run failed with.\n")
+	})
+
+	t.Run("doesn't alert again until the repeat interval has passed", func(t *testing.T) {
+		for i := 0; i < 6; i++ {
+			cycle(map[string]bool{"bullhorn-contacts": false, "bullhorn-placements": false})
+		}
+		assert.Equal(t, len(sent.msgs), 2)
+
+		// Two hours after the first alert about contacts at 10:30
+		cycle(map[string]bool{"bullhorn-contacts": false, "bullhorn-placements": false})
+		assert.Equal(t, len(sent.msgs), 3)
+		assert.Equal(t, sent.msgs[2].Body,
+			"bullhorn-contacts has failed 11 runs in a row, since 2022-06-01T10:00:00Z\n"+
+				"\nThe logs have the errors each run failed with.\n")
+	})
+
+	t.Run("sends a recovery notice", func(t *testing.T) {
+		sent.msgs = nil
+
+		// Two hours after the first alert about placements at 10:45
+		cycle(map[string]bool{"bullhorn-contacts": true, "bullhorn-placements": false})
+		assert.DeepEqual(t, sent.msgs, []Message{{
+			Subject: "bullhorn-to-dataset: 1 dataset failing, 1 dataset recovered",
+			Body: "bullhorn-placements has failed 11 runs in a row, since 2022-06-01T10:15:00Z\n" +
+				"bullhorn-contacts has recovered after failing 11 runs in a row\n" +
+				"\nThe logs have the errors each run failed with.\n",
+		}})
+
+		cycle(map[string]bool{"bullhorn-contacts": true, "bullhorn-placements": true})
+		assert.Equal(t, sent.msgs[1].Subject, "bullhorn-to-dataset: 1 dataset recovered")
+
+		cycle(map[string]bool{"bullhorn-contacts": true, "bullhorn-placements": true})
+		assert.Equal(t, len(sent.msgs), 2)
+	})
+
+	t.Run("logs notifiers which fail", func(t *testing.T) {
+		sent.err = errors.New("connection refused")
+		for i := 0; i < 3; i++ {
+			cycle(map[string]bool{"bullhorn-contacts": false})
+		}
+
+		assert.DeepEqual(t, logs.msgs, []string{"Sending alert with mock failed with error: connection refused\n"})
+	})
+}
+
+func TestMonitor_KeepStateIn(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	dir := filepath.Join(t.TempDir(), "state")
+	sent := &mockNotifier{}
+
+	// Each run is a new process as with --single-run
+	singleRun := func(record func(m *Monitor)) {
+		m := NewMonitor([]Notifier{sent}, 3, 0, &mockLogPrinter{})
+		m.now = func() time.Time { return now }
+		assert.NilError(t, m.KeepStateIn(dir))
+
+		record(m)
+		now = now.Add(15 * time.Minute)
+	}
+
+	failContacts := func(m *Monitor) {
+		m.RecordError("bullhorn-contacts", errors.New("fetching data: timeout"))
+		m.RecordDataset("bullhorn-contacts", time.Second, false)
+		m.RecordCycle(false)
+	}
+
+	singleRun(failContacts)
+	singleRun(failContacts)
+	assert.Equal(t, len(sent.msgs), 0)
+
+	singleRun(failContacts)
+	assert.DeepEqual(t, sent.msgs, []Message{{
+		Subject: "bullhorn-to-dataset: 1 dataset failing",
+		Body: "bullhorn-contacts has failed 3 runs in a row, since 2022-06-01T10:00:00Z, last with error: fetching data: timeout\n" +
+			"\nThe logs have the errors each run failed with.\n",
+	}})
+
+	singleRun(failContacts)
+	assert.Equal(t, len(sent.msgs), 1)
+
+	singleRun(func(m *Monitor) {
+		m.RecordDataset("bullhorn-contacts", time.Second, true)
+		m.RecordCycle(true)
+	})
+	assert.Equal(t, sent.msgs[1].Body, "bullhorn-contacts has recovered after failing 4 runs in a row\n")
+
+	t.Run("returns error when the state is corrupt", func(t *testing.T) {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, "alerts.json"), []byte("{"), 0o644))
+
+		err := NewMonitor(nil, 3, 0, &mockLogPrinter{}).KeepStateIn(dir)
+		assert.ErrorContains(t, err, "reading alert state")
+	})
+}
+
+func TestMonitor_RecordLogin(t *testing.T) {
+	sent := &mockNotifier{}
+	m := NewMonitor([]Notifier{sent}, 2, 0, &mockLogPrinter{})
+	m.now = func() time.Time { return time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC) }
+
+	loginErr := &url.Error{Op: "Get", URL: "https://bullhorn.example.com/login?password=secret", Err: errors.New("connection refused")}
+
+	m.RecordLogin(loginErr)
+	assert.Equal(t, len(sent.msgs), 0)
+
+	m.RecordLogin(loginErr)
+	assert.DeepEqual(t, sent.msgs, []Message{{
+		Subject: "bullhorn-to-dataset: 1 dataset failing",
+		Body: "Logging in to Bullhorn has failed 2 runs in a row, since 2022-06-01T10:00:00Z, " +
+			"last with error: Get \"https://bullhorn.example.com/login\": connection refused\n" +
+			"\nThe logs have the errors each run failed with.\n",
+	}})
+
+	m.RecordLogin(nil)
+	m.RecordCycle(true)
+	assert.Equal(t, sent.msgs[1].Body, "Logging in to Bullhorn has recovered after failing 2 runs in a row\n")
+}
+
+func TestMonitor_message(t *testing.T) {
+	m := NewMonitor(nil, 1, 0, &mockLogPrinter{})
+	m.failing = []string{"b failed", "a failed"}
+	m.recovered = []string{"c recovered"}
+
+	msg, ok := m.message()
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, msg, Message{
+		Subject: "bullhorn-to-dataset: 2 datasets failing, 1 dataset recovered",
+		Body:    "a failed\nb failed\nc recovered\n\nThe logs have the errors each run failed with.\n",
+	})
+
+	m.failing, m.recovered = nil, nil
+	_, ok = m.message()
+	assert.Assert(t, !ok)
+}
+
+type mockNotifier struct {
+	msgs []Message
+	err  error
+}
+
+func (m *mockNotifier) String() string {
+	return "mock"
+}
+
+func (m *mockNotifier) Send(_ context.Context, msg Message) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.msgs = append(m.msgs, msg)
+	return nil
+}
+
+type mockLogPrinter struct {
+	msgs []string
+}
+
+func (m *mockLogPrinter) Printf(format string, v ...interface{}) {
+	m.msgs = append(m.msgs, fmt.Sprintf(format, v...))
+}
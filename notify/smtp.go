@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPOptions configures the server and addresses alerts are emailed with
+type SMTPOptions struct {
+	// Addr is the host:port of the SMTP server
+	Addr string
+	// Username and Password are only used when the username is set
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// SMTP emails alerts, using STARTTLS when the server supports it
+type SMTP struct {
+	options SMTPOptions
+	send    func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	now     func() time.Time
+}
+
+func NewSMTP(options SMTPOptions) (*SMTP, error) {
+	host, _, err := net.SplitHostPort(options.Addr)
+	if err != nil || host == "" {
+		return nil, fmt.Errorf("invalid smtp address %q, expected host:port", options.Addr)
+	}
+
+	if options.From == "" {
+		return nil, fmt.Errorf("smtp from address is required")
+	}
+
+	if len(options.To) == 0 {
+		return nil, fmt.Errorf("at least one smtp to address is required")
+	}
+
+	return &SMTP{options: options, send: smtp.SendMail, now: time.Now}, nil
+}
+
+func (s *SMTP) String() string {
+	return "email"
+}
+
+// Send emails the alert, the context isn't used as
+// net/smtp doesn't take one
+func (s *SMTP) Send(_ context.Context, msg Message) error {
+	var auth smtp.Auth
+	if s.options.Username != "" {
+		host, _, _ := net.SplitHostPort(s.options.Addr)
+		auth = smtp.PlainAuth("", s.options.Username, s.options.Password, host)
+	}
+
+	return s.send(s.options.Addr, auth, s.options.From, s.options.To, s.email(msg))
+}
+
+// email formats the message with the headers email clients need
+func (s *SMTP) email(msg Message) []byte {
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "From: %s\r\n", s.options.From)
+	fmt.Fprintf(b, "To: %s\r\n", strings.Join(s.options.To, ", "))
+	fmt.Fprintf(b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(b, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
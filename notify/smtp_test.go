@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"context"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNewSMTP(t *testing.T) {
+	_, err := NewSMTP(SMTPOptions{Addr: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}})
+	assert.Error(t, err, `invalid smtp address "smtp.example.com", expected host:port`)
+
+	_, err = NewSMTP(SMTPOptions{Addr: "smtp.example.com:587", To: []string{"b@example.com"}})
+	assert.Error(t, err, "smtp from address is required")
+
+	_, err = NewSMTP(SMTPOptions{Addr: "smtp.example.com:587", From: "a@example.com"})
+	assert.Error(t, err, "at least one smtp to address is required")
+}
+
+func TestSMTP_Send(t *testing.T) {
+	s, err := NewSMTP(SMTPOptions{
+		Addr:     "smtp.example.com:587",
+		Username: "user",
+		Password: "pass",
+		From:     "alerts@example.com",
+		To:       []string{"a@example.com", "b@example.com"},
+	})
+	assert.NilError(t, err)
+
+	s.now = func() time.Time {
+		return time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	}
+
+	var gotAddr, gotFrom, gotMsg string
+	var gotTo []string
+	var gotAuth smtp.Auth
+
+	s.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotFrom, gotTo, gotMsg = addr, a, from, to, string(msg)
+		return nil
+	}
+
+	err = s.Send(context.Background(), Message{Subject: "1 dataset failing", Body: "line one\nline two\n"})
+	assert.NilError(t, err)
+
+	assert.Equal(t, gotAddr, "smtp.example.com:587")
+	assert.Assert(t, gotAuth != nil)
+	assert.Equal(t, gotFrom, "alerts@example.com")
+	assert.DeepEqual(t, gotTo, []string{"a@example.com", "b@example.com"})
+	assert.Equal(t, gotMsg, "From: alerts@example.com\r\n"+
+		"To: a@example.com, b@example.com\r\n"+
+		"Subject: 1 dataset failing\r\n"+
+		"Date: Wed, 01 Jun 2022 10:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"\r\n"+
+		"line one\r\nline two\r\n")
+}
@@ -1,6 +1,9 @@
 package printer
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 type Printer interface {
 	Printf(string, ...interface{})
@@ -11,3 +14,13 @@ type LogPrinter struct{}
 func (LogPrinter) Printf(format string, v ...interface{}) {
 	fmt.Printf(format, v...)
 }
+
+// WriterPrinter prints to the writer, such as stderr
+// to keep the progress apart from data written to stdout
+type WriterPrinter struct {
+	W io.Writer
+}
+
+func (p WriterPrinter) Printf(format string, v ...interface{}) {
+	fmt.Fprintf(p.W, format, v...)
+}
@@ -0,0 +1,170 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// rowHashes are the content hashes of the rows last pushed
+// to a dataset keyed by the dataset's unique by values
+type rowHashes struct {
+	Hashes        map[string]string `json:"hashes"`
+	FullRefreshAt time.Time         `json:"full_refresh_at"`
+}
+
+type rowHashStore interface {
+	Load(dataset string) (*rowHashes, error)
+	Save(dataset string, hashes rowHashes) error
+}
+
+type fileRowHashStore struct {
+	dir string
+}
+
+func newFileRowHashStore(dir string) (*fileRowHashStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &fileRowHashStore{dir: dir}, nil
+}
+
+func (s *fileRowHashStore) path(dataset string) string {
+	return filepath.Join(s.dir, dataset+".hashes.json")
+}
+
+// Load returns empty hashes when the dataset hasn't been pushed before
+func (s *fileRowHashStore) Load(dataset string) (*rowHashes, error) {
+	b, err := os.ReadFile(s.path(dataset))
+	if errors.Is(err, os.ErrNotExist) {
+		return &rowHashes{Hashes: map[string]string{}}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := &rowHashes{}
+	if err := json.Unmarshal(b, hashes); err != nil {
+		return nil, fmt.Errorf("reading row hashes of dataset %s: %w", dataset, err)
+	}
+
+	if hashes.Hashes == nil {
+		hashes.Hashes = map[string]string{}
+	}
+
+	return hashes, nil
+}
+
+func (s *fileRowHashStore) Save(dataset string, hashes rowHashes) error {
+	b, err := json.Marshal(hashes)
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path(dataset) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path(dataset))
+}
+
+// changeDetection only lets through rows which are new or have changed
+// since the last successful push, every row is pushed again once the
+// full refresh interval has passed in case the dataset was changed
+type changeDetection struct {
+	store               rowHashStore
+	fullRefreshInterval time.Duration
+	now                 func() time.Time
+}
+
+type changeStats struct {
+	new, changed, unchanged int
+	fullRefresh             bool
+}
+
+// changedRows returns the rows to push along with the hashes to save once
+// they have been pushed successfully
+func (c *changeDetection) changedRows(dataset *geckoboard.Dataset, data geckoboard.Data) (geckoboard.Data, rowHashes, changeStats, error) {
+	last, err := c.store.Load(dataset.Name)
+	if err != nil {
+		return nil, rowHashes{}, changeStats{}, err
+	}
+
+	now := c.now()
+	stats := changeStats{
+		fullRefresh: now.Sub(last.FullRefreshAt) >= c.fullRefreshInterval,
+	}
+
+	next := rowHashes{
+		Hashes:        map[string]string{},
+		FullRefreshAt: last.FullRefreshAt,
+	}
+
+	if stats.fullRefresh {
+		next.FullRefreshAt = now
+	}
+
+	changed := geckoboard.Data{}
+	for _, row := range data {
+		key := dataset.RowKey(row)
+		hash, err := hashRow(row)
+		if err != nil {
+			return nil, rowHashes{}, changeStats{}, err
+		}
+
+		// Rows without a key can't be tracked so are always pushed
+		if key == "" {
+			stats.new++
+			changed = append(changed, row)
+			continue
+		}
+
+		next.Hashes[key] = hash
+
+		lastHash, seen := last.Hashes[key]
+		switch {
+		case !seen:
+			stats.new++
+		case lastHash != hash:
+			stats.changed++
+		default:
+			stats.unchanged++
+			if !stats.fullRefresh {
+				continue
+			}
+		}
+
+		changed = append(changed, row)
+	}
+
+	return changed, next, stats, nil
+}
+
+// save records the hashes leaving out the rows Geckoboard didn't accept
+// so they are pushed again next time
+func (c *changeDetection) save(dataset *geckoboard.Dataset, hashes rowHashes, rejected []geckoboard.RejectedRow) error {
+	for _, r := range rejected {
+		delete(hashes.Hashes, r.ID)
+	}
+
+	return c.store.Save(dataset.Name, hashes)
+}
+
+func hashRow(row geckoboard.DataRow) (string, error) {
+	b, err := json.Marshal(row)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
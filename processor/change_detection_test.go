@@ -0,0 +1,191 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFileRowHashStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+
+	store, err := newFileRowHashStore(dir)
+	assert.NilError(t, err)
+
+	t.Run("returns empty hashes when the dataset hasn't been pushed", func(t *testing.T) {
+		got, err := store.Load("bullhorn-placements")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, &rowHashes{Hashes: map[string]string{}})
+	})
+
+	t.Run("saves and loads the hashes", func(t *testing.T) {
+		want := rowHashes{
+			Hashes:        map[string]string{"1": "abc"},
+			FullRefreshAt: time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC),
+		}
+		assert.NilError(t, store.Save("bullhorn-placements", want))
+
+		got, err := store.Load("bullhorn-placements")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, &want)
+
+		_, err = os.Stat(filepath.Join(dir, "bullhorn-placements.hashes.json.tmp"))
+		assert.Assert(t, os.IsNotExist(err))
+	})
+
+	t.Run("returns error when the hashes file is corrupt", func(t *testing.T) {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, "bullhorn-users.hashes.json"), []byte("{"), 0o644))
+
+		_, err := store.Load("bullhorn-users")
+		assert.ErrorContains(t, err, "reading row hashes of dataset bullhorn-users")
+	})
+}
+
+func TestChangeDetection_ChangedRows(t *testing.T) {
+	dataset := &geckoboard.Dataset{Name: "bullhorn-placements", UniqueBy: []string{"id"}}
+	lastRefresh := time.Date(2022, 5, 5, 10, 0, 0, 0, time.UTC)
+
+	newDetection := func(t *testing.T, now time.Time) *changeDetection {
+		store, err := newFileRowHashStore(t.TempDir())
+		assert.NilError(t, err)
+
+		return &changeDetection{
+			store:               store,
+			fullRefreshInterval: 24 * time.Hour,
+			now:                 func() time.Time { return now },
+		}
+	}
+
+	pushed := geckoboard.Data{
+		{"id": "1", "title": "one"},
+		{"id": "2", "title": "two"},
+	}
+
+	t.Run("pushes every row the first time", func(t *testing.T) {
+		c := newDetection(t, lastRefresh)
+
+		got, hashes, stats, err := c.changedRows(dataset, pushed)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, pushed)
+		assert.Equal(t, len(hashes.Hashes), 2)
+		assert.Equal(t, hashes.FullRefreshAt, lastRefresh)
+		assert.Equal(t, stats.new, 2)
+		assert.Equal(t, stats.changed, 0)
+		assert.Equal(t, stats.unchanged, 0)
+		assert.Assert(t, stats.fullRefresh)
+	})
+
+	t.Run("only pushes new and changed rows", func(t *testing.T) {
+		c := newDetection(t, lastRefresh)
+
+		_, hashes, _, err := c.changedRows(dataset, pushed)
+		assert.NilError(t, err)
+		assert.NilError(t, c.save(dataset, hashes, nil))
+
+		c.now = func() time.Time { return lastRefresh.Add(time.Hour) }
+		got, hashes, stats, err := c.changedRows(dataset, geckoboard.Data{
+			{"id": "1", "title": "one"},
+			{"id": "2", "title": "two updated"},
+			{"id": "3", "title": "three"},
+		})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, geckoboard.Data{
+			{"id": "2", "title": "two updated"},
+			{"id": "3", "title": "three"},
+		})
+		assert.Equal(t, len(hashes.Hashes), 3)
+		assert.Equal(t, hashes.FullRefreshAt, lastRefresh)
+		assert.Equal(t, stats.new, 1)
+		assert.Equal(t, stats.changed, 1)
+		assert.Equal(t, stats.unchanged, 1)
+		assert.Assert(t, !stats.fullRefresh)
+	})
+
+	t.Run("pushes every row once the full refresh interval has passed", func(t *testing.T) {
+		c := newDetection(t, lastRefresh)
+
+		_, hashes, _, err := c.changedRows(dataset, pushed)
+		assert.NilError(t, err)
+		assert.NilError(t, c.save(dataset, hashes, nil))
+
+		now := lastRefresh.Add(24 * time.Hour)
+		c.now = func() time.Time { return now }
+
+		got, hashes, stats, err := c.changedRows(dataset, pushed)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, pushed)
+		assert.Equal(t, hashes.FullRefreshAt, now)
+		assert.Equal(t, stats.unchanged, 2)
+		assert.Assert(t, stats.fullRefresh)
+	})
+
+	t.Run("always pushes rows without a unique by value", func(t *testing.T) {
+		c := newDetection(t, lastRefresh)
+		data := geckoboard.Data{{"title": "no id"}}
+
+		_, hashes, _, err := c.changedRows(dataset, data)
+		assert.NilError(t, err)
+		assert.NilError(t, c.save(dataset, hashes, nil))
+
+		c.now = func() time.Time { return lastRefresh.Add(time.Hour) }
+		got, hashes, stats, err := c.changedRows(dataset, data)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, data)
+		assert.Equal(t, len(hashes.Hashes), 0)
+		assert.Equal(t, stats.new, 1)
+	})
+
+	t.Run("pushes rejected rows again on the next run", func(t *testing.T) {
+		c := newDetection(t, lastRefresh)
+
+		_, hashes, _, err := c.changedRows(dataset, pushed)
+		assert.NilError(t, err)
+		assert.NilError(t, c.save(dataset, hashes, []geckoboard.RejectedRow{{ID: "2"}}))
+
+		c.now = func() time.Time { return lastRefresh.Add(time.Hour) }
+		got, _, stats, err := c.changedRows(dataset, pushed)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, geckoboard.Data{{"id": "2", "title": "two"}})
+		assert.Equal(t, stats.new, 1)
+		assert.Equal(t, stats.unchanged, 1)
+	})
+}
+
+func TestProcessor_ProcessAll_ChangeDetection(t *testing.T) {
+	sent := []geckoboard.Data{}
+
+	gc := geckoboard.New("", "")
+	gc.DatasetService = mockDatasetService{
+		findOrCreateFn: func(*geckoboard.Dataset) error {
+			return nil
+		},
+		appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+			sent = append(sent, data)
+			return geckoboard.AppendResult{}, nil
+		},
+	}
+
+	proc, logs := defaultNewProcessor(gc, defaultMockProcessor)
+	assert.NilError(t, proc.EnableChangeDetection(t.TempDir(), time.Hour))
+
+	proc.ProcessAll(context.Background())
+	proc.ProcessAll(context.Background())
+
+	assert.Equal(t, len(sent), 2)
+	assert.Equal(t, len(sent[0]), 2)
+	assert.Equal(t, len(sent[1]), 0)
+
+	assert.DeepEqual(t, logs.msgs, []string{
+		"Queried 2 mock model records\n",
+		"Full refresh of mock model, found 2 new, 0 changed and 0 unchanged records\n",
+		"Pushing 2 mock model records to geckoboard\n",
+		"Queried 2 mock model records\n",
+		"Found 0 new, 0 changed and 2 unchanged mock model records\n",
+		"Pushing 0 mock model records to geckoboard\n",
+	})
+}
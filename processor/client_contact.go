@@ -4,15 +4,16 @@ import (
 	"bullhorn-to-dataset/bullhorn"
 	"bullhorn-to-dataset/geckoboard"
 	"context"
-	"fmt"
 	"math"
 	"strconv"
 )
 
 var (
 	contactCustomFieldRules = map[string]int{
-		"Date":  3,
-		"Float": 3,
+		"Date":      3,
+		"Float":     3,
+		"Int":       3,
+		"TextBlock": 5,
 	}
 )
 
@@ -22,6 +23,8 @@ type clientContactProcessor struct {
 	maxDatasetRecords int
 	recordsPerPage    int
 	customFields      customFields
+	nestedFields      nestedFields
+	departments       *ownerDepartmentCache
 }
 
 func (clientContactProcessor) String() string {
@@ -33,12 +36,19 @@ func (c *clientContactProcessor) QueryData(ctx context.Context) (geckoboard.Data
 		return nil, err
 	}
 
+	if err := c.nestedFields.fetchAndValidateNestedFields(c.String()); err != nil {
+		return nil, err
+	}
+
 	contacts, err := c.queryClientContacts(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Println("Queried", len(contacts), "contacts")
+	departments, err := c.departments.get(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	maxIndex := int(math.Min(float64(len(contacts)), float64(c.maxDatasetRecords)))
 	latestContacts := contacts[0:maxIndex]
@@ -58,6 +68,8 @@ func (c *clientContactProcessor) QueryData(ctx context.Context) (geckoboard.Data
 		}
 
 		c.customFields.extractCustomFieldData(cc, entry)
+		c.nestedFields.extractNestedFieldData(cc.Raw, entry)
+		departments.extractOwnerDepartmentData(cc.Owner, entry)
 		data = append(data, entry)
 	}
 
@@ -116,6 +128,8 @@ func (c *clientContactProcessor) Schema() *geckoboard.Dataset {
 	}
 
 	c.customFields.extractCustomFieldsForSchema(datasetFields)
+	c.nestedFields.extractNestedFieldsForSchema(datasetFields)
+	extractOwnerDepartmentForSchema(datasetFields)
 
 	return &geckoboard.Dataset{
 		Name:     "bullhorn-contacts",
@@ -136,11 +150,14 @@ func (c *clientContactProcessor) queryClientContacts(ctx context.Context) ([]bul
 		queryFields = append(queryFields, f.sanitized)
 	}
 
+	queryFields = append(queryFields, c.nestedFields.queryFields()...)
+
 	query := bullhorn.SearchQuery{
-		Fields: queryFields,
-		Where:  "isDeleted=false",
-		Start:  0,
-		Count:  c.recordsPerPage,
+		Fields:     queryFields,
+		Where:      "isDeleted=false",
+		Start:      0,
+		Count:      c.recordsPerPage,
+		IncludeRaw: len(c.nestedFields) > 0,
 	}
 
 	for {
@@ -430,7 +430,7 @@ func TestClientContact_QueryData(t *testing.T) {
 					fields: []string{"customDate2", "customField2"},
 					// Although customText0 isn't really supported for client contact if attempted it error appropriately
 					// Test case for that below
-					wantErr: `unknown contact field "customField2", only customDate0, customText0 and customFloat0 are valid`,
+					wantErr: `unknown contact field "customField2", only customDate0, customText0, customTextBlock0, customFloat0 and customInt0 are valid`,
 				},
 				{
 					name:    "customText1 is not supported",
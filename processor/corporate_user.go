@@ -0,0 +1,118 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var corporateUserFields = []string{
+	"id", "firstName", "lastName", "email",
+	"departments", "primaryDepartment", "enabled",
+}
+
+type corporateUserProcessor struct {
+	client *bullhorn.Client
+
+	maxDatasetRecords int
+	recordsPerPage    int
+}
+
+func (corporateUserProcessor) String() string {
+	return "user"
+}
+
+func (c *corporateUserProcessor) QueryData(ctx context.Context) (geckoboard.Data, error) {
+	users, err := queryCorporateUsers(ctx, c.client, c.recordsPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIndex := int(math.Min(float64(len(users)), float64(c.maxDatasetRecords)))
+	latestUsers := users[0:maxIndex]
+
+	data := geckoboard.Data{}
+	for _, u := range latestUsers {
+		entry := geckoboard.DataRow{
+			"id":                 strconv.Itoa(u.ID),
+			"name":               u.FullName(),
+			"email":              valueOrNil(u.Email),
+			"departments":        valueOrNotSet(u.Departments.Join()),
+			"primary_department": valueOrNotSet(u.PrimaryDepartment.Name),
+			"enabled":            strings.ToUpper(strconv.FormatBool(u.Enabled)),
+		}
+
+		data = append(data, entry)
+	}
+
+	return data, nil
+}
+
+func (c *corporateUserProcessor) Schema() *geckoboard.Dataset {
+	return &geckoboard.Dataset{
+		Name: "bullhorn-users",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"name": {
+				Name:     "Name",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"email": {
+				Name:     "Email",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"departments": {
+				Name:     "Departments",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"primary_department": {
+				Name:     "Primary department",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"enabled": {
+				Name:     "Enabled",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+		},
+		UniqueBy: []string{"id"},
+	}
+}
+
+// queryCorporateUsers pages through every corporate user, it is shared with
+// the owner department lookup which needs all users and not just the latest
+func queryCorporateUsers(ctx context.Context, client *bullhorn.Client, recordsPerPage int) ([]bullhorn.CorporateUser, error) {
+	var users []bullhorn.CorporateUser
+
+	query := bullhorn.SearchQuery{
+		Fields: corporateUserFields,
+		Where:  "isDeleted=false",
+		Start:  0,
+		Count:  recordsPerPage,
+	}
+
+	for {
+		us, err := client.CorporateUserService.Search(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, us.Items...)
+		if len(us.Items) < query.Count {
+			return users, nil
+		}
+
+		query.Start = query.Count + query.Start
+	}
+}
@@ -0,0 +1,214 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var (
+	wantCorporateUserData = geckoboard.Data{
+		{
+			"id":                 "1",
+			"name":               stringPtr("User A"),
+			"email":              stringPtr("user.a@example.com"),
+			"departments":        "London ; Sales",
+			"primary_department": "Sales",
+			"enabled":            "TRUE",
+		},
+		{
+			"id":                 "2",
+			"name":               stringPtr("User B"),
+			"email":              (*string)(nil),
+			"departments":        "(not set)",
+			"primary_department": "(not set)",
+			"enabled":            "FALSE",
+		},
+	}
+
+	testCorporateUsers = []bullhorn.CorporateUser{
+		{
+			Person: bullhorn.Person{ID: 1, FirstName: "User", LastName: "A"},
+			Email:  "user.a@example.com",
+			Departments: bullhorn.Departments{
+				Data: []bullhorn.NestedEntity{
+					{ID: 10, Name: "Sales"},
+					{ID: 11, Name: "London"},
+				},
+			},
+			PrimaryDepartment: bullhorn.NestedEntity{ID: 10, Name: "Sales"},
+			Enabled:           true,
+		},
+		{
+			Person: bullhorn.Person{ID: 2, FirstName: "User", LastName: "B"},
+		},
+	}
+)
+
+func TestCorporateUser_String(t *testing.T) {
+	p := corporateUserProcessor{}
+	assert.Equal(t, p.String(), "user")
+}
+
+func TestCorporateUser_Schema(t *testing.T) {
+	got := (&corporateUserProcessor{}).Schema()
+	want := &geckoboard.Dataset{
+		Name: "bullhorn-users",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"name": {
+				Name:     "Name",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"email": {
+				Name:     "Email",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"departments": {
+				Name:     "Departments",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"primary_department": {
+				Name:     "Primary department",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"enabled": {
+				Name:     "Enabled",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+		},
+		UniqueBy: []string{"id"},
+	}
+
+	assert.DeepEqual(t, got, want)
+}
+
+func TestCorporateUser_QueryData(t *testing.T) {
+	t.Run("returns all records successfully", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.CorporateUserService = newCorporateUserService(t, testCorporateUsers)
+
+		proc := corporateUserProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantCorporateUserData)
+	})
+
+	t.Run("paginates until records are less than the count", func(t *testing.T) {
+		bullhornRequests := 0
+
+		bc := bullhorn.New("")
+		bc.CorporateUserService = mockCorporateUserService{
+			searchFn: func(got bullhorn.SearchQuery) (*bullhorn.CorporateUsers, error) {
+				bullhornRequests += 1
+				want := bullhorn.SearchQuery{
+					Fields: corporateUserFields,
+					Where:  "isDeleted=false",
+					Count:  1,
+				}
+
+				switch bullhornRequests {
+				case 1:
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.CorporateUsers{Items: testCorporateUsers[:1]}, nil
+				case 2:
+					want.Start = 1
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.CorporateUsers{Items: testCorporateUsers[1:]}, nil
+				case 3:
+					want.Start = 2
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.CorporateUsers{}, nil
+				}
+
+				return nil, errors.New("shouldn't have got here")
+			},
+		}
+
+		proc := corporateUserProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    1,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantCorporateUserData)
+	})
+
+	t.Run("returns only the max dataset records", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.CorporateUserService = newCorporateUserService(t, testCorporateUsers)
+
+		proc := corporateUserProcessor{
+			client:            bc,
+			maxDatasetRecords: 1,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantCorporateUserData[:1])
+	})
+
+	t.Run("returns error when user query fails", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.CorporateUserService = mockCorporateUserService{
+			searchFn: func(bullhorn.SearchQuery) (*bullhorn.CorporateUsers, error) {
+				return nil, errors.New("query users failed")
+			},
+		}
+
+		proc := corporateUserProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		_, err := proc.QueryData(context.Background())
+		assert.Error(t, err, "query users failed")
+	})
+}
+
+type mockCorporateUserService struct {
+	searchFn func(bullhorn.SearchQuery) (*bullhorn.CorporateUsers, error)
+}
+
+func newCorporateUserService(t *testing.T, recs []bullhorn.CorporateUser) mockCorporateUserService {
+	return mockCorporateUserService{
+		searchFn: func(got bullhorn.SearchQuery) (*bullhorn.CorporateUsers, error) {
+			want := bullhorn.SearchQuery{
+				Fields: corporateUserFields,
+				Where:  "isDeleted=false",
+				Count:  200,
+			}
+
+			assert.DeepEqual(t, got, want)
+			return &bullhorn.CorporateUsers{
+				Items: recs,
+			}, nil
+		},
+	}
+}
+
+func (m mockCorporateUserService) Search(_ context.Context, query bullhorn.SearchQuery) (*bullhorn.CorporateUsers, error) {
+	return m.searchFn(query)
+}
@@ -11,7 +11,17 @@ import (
 	"strings"
 )
 
-var customFieldRegexp = regexp.MustCompile(`^(custom)(Date|Text|Float)(\d{1,2})$`)
+var customFieldRegexp = regexp.MustCompile(`^(custom)(Date|TextBlock|Text|Float|Int)(\d{1,2})$`)
+
+// customFieldTypes are the Geckoboard types each kind of
+// custom field can be pushed as, the first being the default
+var customFieldTypes = map[string][]geckoboard.FieldType{
+	"Date":      {geckoboard.DatetimeType, geckoboard.DateType},
+	"Text":      {geckoboard.StringType},
+	"TextBlock": {geckoboard.StringType},
+	"Float":     {geckoboard.NumberType, geckoboard.MoneyType, geckoboard.PercentType, geckoboard.DurationType},
+	"Int":       {geckoboard.NumberType, geckoboard.MoneyType, geckoboard.PercentType, geckoboard.DurationType},
+}
 
 type customFieldError struct {
 	entity string
@@ -21,11 +31,16 @@ type customFieldError struct {
 	notSupported bool
 	underRange   bool
 	maxRange     int
+	invalidType  string
 }
 
 func (e customFieldError) Error() string {
+	if e.invalidType != "" {
+		return fmt.Sprintf("custom %s field %q, can't be pushed as %s", e.entity, e.field, e.invalidType)
+	}
+
 	if e.fieldInvalid {
-		return fmt.Sprintf("unknown %s field %q, only customDate0, customText0 and customFloat0 are valid", e.entity, e.field)
+		return fmt.Sprintf("unknown %s field %q, only customDate0, customText0, customTextBlock0, customFloat0 and customInt0 are valid", e.entity, e.field)
 	}
 
 	if e.underRange {
@@ -45,6 +60,25 @@ type customField struct {
 	structField  string
 	fieldType    string
 	displayName  string
+
+	// geckoType and timeUnit are set when the field
+	// was given a type suffix like customFloat1:money
+	geckoType geckoboard.FieldType
+	timeUnit  geckoboard.TimeUnit
+}
+
+// schemaType returns the Geckoboard type of the field
+// falling back to the default for its kind of custom field
+func (f customField) schemaType() geckoboard.FieldType {
+	if f.geckoType != "" {
+		return f.geckoType
+	}
+
+	if types, ok := customFieldTypes[f.fieldType]; ok {
+		return types[0]
+	}
+
+	return geckoboard.StringType
 }
 
 type customFields []customField
@@ -57,7 +91,7 @@ func (cfs *customFields) fetchAndValidateCustomFields(entity string, rules map[s
 
 	rawFields := strings.Split(env, ",")
 	for _, f := range rawFields {
-		field := strings.TrimSpace(f)
+		field, suffix := splitTypeSuffix(strings.TrimSpace(f))
 
 		if !customFieldRegexp.MatchString(field) {
 			return customFieldError{
@@ -89,13 +123,26 @@ func (cfs *customFields) fetchAndValidateCustomFields(entity string, rules map[s
 			return err
 		}
 
-		*cfs = append(*cfs, customField{
+		cf := customField{
 			sanitized:    field,
 			datasetField: strings.ToLower(strings.Join(parts[1:], "_")),
 			structField:  strings.Title(field),
 			displayName:  strings.Join(parts[1:], " "),
 			fieldType:    parts[2],
-		})
+		}
+
+		if suffix != "" {
+			fieldType, timeUnit, ok := parseTypeSuffix(suffix)
+			if !ok || !containsFieldType(customFieldTypes[cf.fieldType], fieldType) {
+				err.invalidType = suffix
+				return err
+			}
+
+			cf.geckoType = fieldType
+			cf.timeUnit = timeUnit
+		}
+
+		*cfs = append(*cfs, cf)
 	}
 
 	return nil
@@ -106,40 +153,49 @@ func (cfs customFields) extractCustomFieldData(entity interface{}, row geckoboar
 
 	for _, f := range cfs {
 		val := ref.FieldByName(f.structField)
+		isMoney := f.schemaType() == geckoboard.MoneyType
 
 		switch f.fieldType {
 		case "Text":
 			row[f.datasetField] = val.String()
+		case "TextBlock":
+			row[f.datasetField] = truncateString(val.String(), geckoboard.MaxStringLength)
 		case "Float":
-			row[f.datasetField] = val.Float()
+			if isMoney {
+				row[f.datasetField] = geckoboard.MoneyValue(val.Float())
+			} else {
+				row[f.datasetField] = val.Float()
+			}
+		case "Int":
+			if isMoney {
+				row[f.datasetField] = geckoboard.MoneyValue(float64(val.Int()))
+			} else {
+				row[f.datasetField] = val.Int()
+			}
 		case "Date":
 			epoch, _ := val.Interface().(bullhorn.EpochMilli)
-			row[f.datasetField] = valueOrNil(epoch.String())
+			if f.schemaType() == geckoboard.DateType {
+				row[f.datasetField] = dateValue(epoch)
+			} else {
+				row[f.datasetField] = valueOrNil(epoch.String())
+			}
 		}
 	}
 }
 
 func (cfs customFields) extractCustomFieldsForSchema(fields map[string]geckoboard.Field) {
 	for _, f := range cfs {
-		switch f.fieldType {
-		case "Text":
-			fields[f.datasetField] = geckoboard.Field{
-				Name:     f.displayName,
-				Type:     geckoboard.StringType,
-				Optional: true,
-			}
-		case "Float":
-			fields[f.datasetField] = geckoboard.Field{
-				Name:     f.displayName,
-				Type:     geckoboard.NumberType,
-				Optional: true,
-			}
-		case "Date":
-			fields[f.datasetField] = geckoboard.Field{
-				Name:     f.displayName,
-				Type:     geckoboard.DatetimeType,
-				Optional: true,
-			}
-		}
+		fields[f.datasetField] = schemaField(f.displayName, f.schemaType(), f.timeUnit)
 	}
 }
+
+// truncateString cuts the value down to max characters, text blocks
+// are often longer than Geckoboard will accept for a string field
+func truncateString(v string, max int) string {
+	runes := []rune(v)
+	if len(runes) <= max {
+		return v
+	}
+
+	return string(runes[:max])
+}
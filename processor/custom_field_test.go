@@ -1,6 +1,10 @@
 package processor
 
 import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"os"
+	"strings"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -15,6 +19,7 @@ func TestCustomFieldError_Error(t *testing.T) {
 			underRange:   override.underRange,
 			notSupported: override.notSupported,
 			maxRange:     override.maxRange,
+			invalidType:  override.invalidType,
 		}
 	}
 	specs := []struct {
@@ -25,7 +30,7 @@ func TestCustomFieldError_Error(t *testing.T) {
 		{
 			name:    "invalid custom field name",
 			in:      defaultCustomFieldError(customFieldError{fieldInvalid: true}),
-			wantErr: `unknown testmock field "customField1", only customDate0, customText0 and customFloat0 are valid`,
+			wantErr: `unknown testmock field "customField1", only customDate0, customText0, customTextBlock0, customFloat0 and customInt0 are valid`,
 		},
 		{
 			name:    "custom date field under range",
@@ -37,6 +42,11 @@ func TestCustomFieldError_Error(t *testing.T) {
 			in:      defaultCustomFieldError(customFieldError{notSupported: true}),
 			wantErr: `custom field "customField1", is not supported for testmock`,
 		},
+		{
+			name:    "custom field type not supported",
+			in:      defaultCustomFieldError(customFieldError{invalidType: "money"}),
+			wantErr: `custom testmock field "customField1", can't be pushed as money`,
+		},
 		{
 			name:    "custom date field over range",
 			in:      defaultCustomFieldError(customFieldError{maxRange: 13}),
@@ -50,3 +60,117 @@ func TestCustomFieldError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomFields_IntAndTextBlock(t *testing.T) {
+	defer os.Unsetenv("PLACEMENT_CUSTOMFIELDS")
+	os.Setenv("PLACEMENT_CUSTOMFIELDS", "customInt2,customTextBlock1")
+
+	cfs := customFields{}
+	err := cfs.fetchAndValidateCustomFields("placement", placementCustomFieldRules)
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(cfs), 2)
+	assert.Equal(t, cfs[0].datasetField, "custom_int_2")
+	assert.Equal(t, cfs[0].structField, "CustomInt2")
+	assert.Equal(t, cfs[0].fieldType, "Int")
+	assert.Equal(t, cfs[1].datasetField, "custom_textblock_1")
+	assert.Equal(t, cfs[1].structField, "CustomTextBlock1")
+	assert.Equal(t, cfs[1].fieldType, "TextBlock")
+
+	t.Run("extracts the values truncating text blocks", func(t *testing.T) {
+		row := geckoboard.DataRow{}
+		cfs.extractCustomFieldData(bullhorn.Placement{
+			CustomInt2:       42,
+			CustomTextBlock1: strings.Repeat("a", 300),
+		}, row)
+
+		assert.DeepEqual(t, row, geckoboard.DataRow{
+			"custom_int_2":       int64(42),
+			"custom_textblock_1": strings.Repeat("a", 255),
+		})
+	})
+
+	t.Run("maps ints to numbers and text blocks to strings", func(t *testing.T) {
+		fields := map[string]geckoboard.Field{}
+		cfs.extractCustomFieldsForSchema(fields)
+
+		assert.DeepEqual(t, fields, map[string]geckoboard.Field{
+			"custom_int_2": {
+				Name:     "custom Int 2",
+				Type:     geckoboard.NumberType,
+				Optional: true,
+			},
+			"custom_textblock_1": {
+				Name:     "custom TextBlock 1",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+		})
+	})
+}
+
+func TestTruncateString(t *testing.T) {
+	assert.Equal(t, truncateString("short", 10), "short")
+	assert.Equal(t, truncateString("longer value", 6), "longer")
+	assert.Equal(t, truncateString("héllo", 2), "hé")
+}
+
+func TestCustomFields_TypeSuffix(t *testing.T) {
+	defer os.Unsetenv("PLACEMENT_CUSTOMFIELDS")
+
+	t.Run("pushes the fields as the given types", func(t *testing.T) {
+		os.Setenv("PLACEMENT_CUSTOMFIELDS", "customFloat1:money,customInt1:duration:minutes,customDate1:date,customFloat2:percentage")
+
+		cfs := customFields{}
+		err := cfs.fetchAndValidateCustomFields("placement", placementCustomFieldRules)
+		assert.NilError(t, err)
+		assert.Equal(t, len(cfs), 4)
+		assert.Equal(t, cfs[0].sanitized, "customFloat1")
+
+		row := geckoboard.DataRow{}
+		cfs.extractCustomFieldData(bullhorn.Placement{
+			CustomFloat1: 12.5,
+			CustomInt1:   90,
+			CustomDate1:  1659111234000,
+			CustomFloat2: 0.25,
+		}, row)
+
+		assert.DeepEqual(t, row, geckoboard.DataRow{
+			"custom_float_1": 1250,
+			"custom_int_1":   int64(90),
+			"custom_date_1":  stringPtr("2022-07-29"),
+			"custom_float_2": 0.25,
+		})
+
+		fields := map[string]geckoboard.Field{}
+		cfs.extractCustomFieldsForSchema(fields)
+
+		assert.DeepEqual(t, fields, map[string]geckoboard.Field{
+			"custom_float_1": {Name: "custom Float 1", Type: geckoboard.MoneyType, Optional: true, CurrencyCode: "USD"},
+			"custom_int_1":   {Name: "custom Int 1", Type: geckoboard.DurationType, Optional: true, TimeUnit: geckoboard.Minutes},
+			"custom_date_1":  {Name: "custom Date 1", Type: geckoboard.DateType, Optional: true},
+			"custom_float_2": {Name: "custom Float 2", Type: geckoboard.PercentType, Optional: true},
+		})
+	})
+
+	t.Run("leaves an unset date empty", func(t *testing.T) {
+		os.Setenv("PLACEMENT_CUSTOMFIELDS", "customDate1:date")
+
+		cfs := customFields{}
+		assert.NilError(t, cfs.fetchAndValidateCustomFields("placement", placementCustomFieldRules))
+
+		row := geckoboard.DataRow{}
+		cfs.extractCustomFieldData(bullhorn.Placement{}, row)
+		assert.DeepEqual(t, row, geckoboard.DataRow{"custom_date_1": (*string)(nil)})
+	})
+
+	for _, field := range []string{"customText1:money", "customDate1:number", "customInt1:duration:days", "customFloat1:cost"} {
+		t.Run("errors for "+field, func(t *testing.T) {
+			os.Setenv("PLACEMENT_CUSTOMFIELDS", field)
+
+			cfs := customFields{}
+			err := cfs.fetchAndValidateCustomFields("placement", placementCustomFieldRules)
+			assert.ErrorContains(t, err, "can't be pushed as")
+		})
+	}
+}
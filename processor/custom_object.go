@@ -0,0 +1,249 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	customObjectFieldRegexp = regexp.MustCompile(`^(text|date|int|float|textBlock)(\d{1,2})$`)
+
+	customObjectFieldRules = map[string]int{
+		"text":      40,
+		"textBlock": 10,
+		"date":      10,
+		"int":       10,
+		"float":     10,
+	}
+)
+
+type customObjectField struct {
+	sanitized    string
+	datasetField string
+	fieldType    string
+	displayName  string
+}
+
+type customObjectProcessor struct {
+	client *bullhorn.Client
+	entity string
+
+	maxDatasetRecords int
+	recordsPerPage    int
+	fields            []customObjectField
+}
+
+// customObjectProcessors returns a processor for each custom object
+// instance listed in the CUSTOMOBJECTS environment variable
+func customObjectProcessors(bc *bullhorn.Client) ([]datasetProcessor, error) {
+	entities, err := customObjectEntities()
+	if err != nil {
+		return nil, err
+	}
+
+	var processors []datasetProcessor
+	for _, entity := range entities {
+		processors = append(processors, &customObjectProcessor{
+			client:            bc,
+			entity:            entity,
+			maxDatasetRecords: maxDatasetRecords,
+			recordsPerPage:    maxRecordsPerPage,
+		})
+	}
+
+	return processors, nil
+}
+
+// customObjectEntities returns the custom object instances listed in
+// CUSTOMOBJECTS once each, skipping empty entries and rejecting unknown ones
+func customObjectEntities() ([]string, error) {
+	env := os.Getenv("CUSTOMOBJECTS")
+	if env == "" {
+		return nil, nil
+	}
+
+	var entities []string
+	seen := map[string]bool{}
+	for _, e := range strings.Split(env, ",") {
+		entity := strings.TrimSpace(e)
+		if entity == "" || seen[entity] {
+			continue
+		}
+
+		if _, err := bullhorn.CustomObjectParentField(entity); err != nil {
+			return nil, fmt.Errorf("invalid CUSTOMOBJECTS: %w", err)
+		}
+
+		seen[entity] = true
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+func (c *customObjectProcessor) String() string {
+	return c.entity
+}
+
+func (c *customObjectProcessor) QueryData(ctx context.Context) (geckoboard.Data, error) {
+	parentField, err := bullhorn.CustomObjectParentField(c.entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.fetchAndValidateFields(); err != nil {
+		return nil, err
+	}
+
+	instances, err := c.queryInstances(ctx, parentField)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIndex := int(math.Min(float64(len(instances)), float64(c.maxDatasetRecords)))
+	latestInstances := instances[0:maxIndex]
+
+	data := geckoboard.Data{}
+	for _, inst := range latestInstances {
+		entry := geckoboard.DataRow{
+			"id":         strconv.Itoa(int(inst.Float("id"))),
+			"parent_id":  strconv.Itoa(inst.NestedID(parentField)),
+			"date_added": valueOrNil(inst.EpochMilli("dateAdded").String()),
+			"updated_at": valueOrNil(inst.EpochMilli("dateLastModified").String()),
+		}
+
+		for _, f := range c.fields {
+			switch f.fieldType {
+			case "text", "textBlock":
+				entry[f.datasetField] = inst.String(f.sanitized)
+			case "int", "float":
+				entry[f.datasetField] = inst.Float(f.sanitized)
+			case "date":
+				entry[f.datasetField] = valueOrNil(inst.EpochMilli(f.sanitized).String())
+			}
+		}
+
+		data = append(data, entry)
+	}
+
+	return data, nil
+}
+
+func (c *customObjectProcessor) Schema() *geckoboard.Dataset {
+	datasetFields := map[string]geckoboard.Field{
+		"id": {
+			Name:     "ID",
+			Type:     geckoboard.StringType,
+			Optional: false,
+		},
+		"parent_id": {
+			Name:     "Parent ID",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+		"date_added": {
+			Name: "Date added", Type: geckoboard.DatetimeType,
+			Optional: true,
+		},
+		"updated_at": {
+			Name: "Updated at", Type: geckoboard.DatetimeType,
+			Optional: true,
+		},
+	}
+
+	for _, f := range c.fields {
+		field := geckoboard.Field{Name: f.displayName, Optional: true}
+
+		switch f.fieldType {
+		case "text", "textBlock":
+			field.Type = geckoboard.StringType
+		case "int", "float":
+			field.Type = geckoboard.NumberType
+		case "date":
+			field.Type = geckoboard.DatetimeType
+		}
+
+		datasetFields[f.datasetField] = field
+	}
+
+	return &geckoboard.Dataset{
+		Name:     "bullhorn-" + strings.ToLower(c.entity),
+		Fields:   datasetFields,
+		UniqueBy: []string{"id"},
+	}
+}
+
+// fetchAndValidateFields reads the custom object fields to include from the
+// environment variable named after the entity such as PERSONCUSTOMOBJECTINSTANCE1_FIELDS
+func (c *customObjectProcessor) fetchAndValidateFields() error {
+	c.fields = nil
+
+	env := os.Getenv(fmt.Sprintf("%s_FIELDS", strings.ToUpper(c.entity)))
+	if env == "" {
+		return nil
+	}
+
+	for _, f := range strings.Split(env, ",") {
+		field := strings.TrimSpace(f)
+
+		parts := customObjectFieldRegexp.FindStringSubmatch(field)
+		if parts == nil {
+			return fmt.Errorf("unknown %s field %q, only text0, textBlock0, date0, int0 and float0 are valid", c.entity, field)
+		}
+
+		num, _ := strconv.Atoi(parts[2])
+		if num <= 0 {
+			return fmt.Errorf("%s field %q, is out of range min field number is 1", c.entity, field)
+		}
+
+		if maxRange := customObjectFieldRules[parts[1]]; num > maxRange {
+			return fmt.Errorf("%s field %q, is out of range max field number is %d", c.entity, field, maxRange)
+		}
+
+		c.fields = append(c.fields, customObjectField{
+			sanitized:    field,
+			datasetField: strings.ToLower(parts[1]) + "_" + parts[2],
+			fieldType:    parts[1],
+			displayName:  strings.Title(parts[1]) + " " + parts[2],
+		})
+	}
+
+	return nil
+}
+
+func (c *customObjectProcessor) queryInstances(ctx context.Context, parentField string) ([]bullhorn.CustomObjectInstance, error) {
+	var instances []bullhorn.CustomObjectInstance
+
+	queryFields := []string{"id", "dateAdded", "dateLastModified", parentField}
+	for _, f := range c.fields {
+		queryFields = append(queryFields, f.sanitized)
+	}
+
+	query := bullhorn.SearchQuery{
+		Fields: queryFields,
+		Where:  "id>0",
+		Start:  0,
+		Count:  c.recordsPerPage,
+	}
+
+	for {
+		is, err := c.client.CustomObjectService.Search(ctx, c.entity, query)
+		if err != nil {
+			return nil, err
+		}
+
+		instances = append(instances, is.Items...)
+		if len(is.Items) < query.Count || len(instances) >= c.maxDatasetRecords {
+			return instances, nil
+		}
+
+		query.Start = query.Count + query.Start
+	}
+}
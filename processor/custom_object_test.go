@@ -0,0 +1,284 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+var (
+	testCustomObjectInstances = []bullhorn.CustomObjectInstance{
+		{
+			"id":               float64(2),
+			"dateAdded":        float64(1659190221000),
+			"dateLastModified": float64(1659193221000),
+			"person":           map[string]interface{}{"id": float64(44)},
+			"text1":            "Passed",
+			"date1":            float64(1659180221000),
+			"int1":             float64(3),
+		},
+		{
+			"id":        float64(1),
+			"dateAdded": float64(1659170221000),
+			"person":    map[string]interface{}{"id": float64(45)},
+			"text1":     "Failed",
+		},
+	}
+)
+
+func TestCustomObjectProcessors(t *testing.T) {
+	t.Run("returns no processors when env not set", func(t *testing.T) {
+		got, err := customObjectProcessors(&bullhorn.Client{})
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Len(got, 0))
+	})
+
+	t.Run("returns a processor for each custom object", func(t *testing.T) {
+		defer os.Unsetenv("CUSTOMOBJECTS")
+		os.Setenv("CUSTOMOBJECTS", "PersonCustomObjectInstance1 , JobOrderCustomObjectInstance2")
+
+		bc := &bullhorn.Client{}
+		got, err := customObjectProcessors(bc)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Len(got, 2))
+
+		for i, entity := range []string{"PersonCustomObjectInstance1", "JobOrderCustomObjectInstance2"} {
+			proc := got[i].(*customObjectProcessor)
+			assert.Equal(t, proc.client, bc)
+			assert.Equal(t, proc.entity, entity)
+			assert.Equal(t, proc.maxDatasetRecords, maxDatasetRecords)
+			assert.Equal(t, proc.recordsPerPage, maxRecordsPerPage)
+		}
+	})
+
+	t.Run("skips empty and repeated custom objects", func(t *testing.T) {
+		defer os.Unsetenv("CUSTOMOBJECTS")
+		os.Setenv("CUSTOMOBJECTS", "PersonCustomObjectInstance1,, PersonCustomObjectInstance1 ,PlacementCustomObjectInstance3,")
+
+		got, err := customObjectProcessors(&bullhorn.Client{})
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Len(got, 2))
+		assert.Equal(t, got[0].String(), "PersonCustomObjectInstance1")
+		assert.Equal(t, got[1].String(), "PlacementCustomObjectInstance3")
+	})
+
+	t.Run("returns error for an unknown custom object", func(t *testing.T) {
+		defer os.Unsetenv("CUSTOMOBJECTS")
+		os.Setenv("CUSTOMOBJECTS", "PersonCustomObjectInstance1,CandidateCustomObjectInstance1")
+
+		_, err := customObjectProcessors(&bullhorn.Client{})
+		assert.Error(t, err, `invalid CUSTOMOBJECTS: unknown custom object "CandidateCustomObjectInstance1", expected for example PersonCustomObjectInstance1`)
+	})
+}
+
+func TestCustomObject_String(t *testing.T) {
+	p := &customObjectProcessor{entity: "PersonCustomObjectInstance1"}
+	assert.Equal(t, p.String(), "PersonCustomObjectInstance1")
+}
+
+func TestCustomObject_Schema(t *testing.T) {
+	p := &customObjectProcessor{
+		entity: "PersonCustomObjectInstance1",
+		fields: []customObjectField{
+			{datasetField: "text_1", fieldType: "text", displayName: "Text 1"},
+			{datasetField: "textblock_1", fieldType: "textBlock", displayName: "TextBlock 1"},
+			{datasetField: "date_1", fieldType: "date", displayName: "Date 1"},
+			{datasetField: "int_1", fieldType: "int", displayName: "Int 1"},
+			{datasetField: "float_1", fieldType: "float", displayName: "Float 1"},
+		},
+	}
+
+	assert.DeepEqual(t, p.Schema(), &geckoboard.Dataset{
+		Name: "bullhorn-personcustomobjectinstance1",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"parent_id": {
+				Name:     "Parent ID",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"date_added": {
+				Name: "Date added", Type: geckoboard.DatetimeType,
+				Optional: true,
+			},
+			"updated_at": {
+				Name: "Updated at", Type: geckoboard.DatetimeType,
+				Optional: true,
+			},
+			"text_1":      {Name: "Text 1", Type: geckoboard.StringType, Optional: true},
+			"textblock_1": {Name: "TextBlock 1", Type: geckoboard.StringType, Optional: true},
+			"date_1":      {Name: "Date 1", Type: geckoboard.DatetimeType, Optional: true},
+			"int_1":       {Name: "Int 1", Type: geckoboard.NumberType, Optional: true},
+			"float_1":     {Name: "Float 1", Type: geckoboard.NumberType, Optional: true},
+		},
+		UniqueBy: []string{"id"},
+	})
+}
+
+func TestCustomObject_QueryData(t *testing.T) {
+	unsetEnv := func() {
+		os.Unsetenv("PERSONCUSTOMOBJECTINSTANCE1_FIELDS")
+	}
+
+	t.Run("queries the declared fields and sets them in the dataset", func(t *testing.T) {
+		defer unsetEnv()
+		os.Setenv("PERSONCUSTOMOBJECTINSTANCE1_FIELDS", "text1, date1, int1")
+
+		bc := bullhorn.New("")
+		bc.CustomObjectService = mockCustomObjectService{
+			searchFn: func(entity string, got bullhorn.SearchQuery) (*bullhorn.CustomObjectInstances, error) {
+				assert.Equal(t, entity, "PersonCustomObjectInstance1")
+				assert.DeepEqual(t, got, bullhorn.SearchQuery{
+					Fields: []string{"id", "dateAdded", "dateLastModified", "person", "text1", "date1", "int1"},
+					Where:  "id>0",
+					Count:  200,
+				})
+
+				return &bullhorn.CustomObjectInstances{Items: testCustomObjectInstances}, nil
+			},
+		}
+
+		proc := &customObjectProcessor{
+			client:            bc,
+			entity:            "PersonCustomObjectInstance1",
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, geckoboard.Data{
+			{
+				"id":         "2",
+				"parent_id":  "44",
+				"date_added": stringPtr("2022-07-30T14:10:21Z"),
+				"updated_at": stringPtr("2022-07-30T15:00:21Z"),
+				"text_1":     "Passed",
+				"date_1":     stringPtr("2022-07-30T11:23:41Z"),
+				"int_1":      float64(3),
+			},
+			{
+				"id":         "1",
+				"parent_id":  "45",
+				"date_added": stringPtr("2022-07-30T08:37:01Z"),
+				"updated_at": (*string)(nil),
+				"text_1":     "Failed",
+				"date_1":     (*string)(nil),
+				"int_1":      float64(0),
+			},
+		})
+
+		_, ok := proc.Schema().Fields["int_1"]
+		assert.Assert(t, ok)
+	})
+
+	t.Run("paginates until records are less than the count", func(t *testing.T) {
+		requests := 0
+
+		bc := bullhorn.New("")
+		bc.CustomObjectService = mockCustomObjectService{
+			searchFn: func(_ string, got bullhorn.SearchQuery) (*bullhorn.CustomObjectInstances, error) {
+				requests += 1
+				assert.Equal(t, got.Start, requests-1)
+
+				if requests == 1 {
+					return &bullhorn.CustomObjectInstances{Items: testCustomObjectInstances[:1]}, nil
+				}
+
+				return &bullhorn.CustomObjectInstances{}, nil
+			},
+		}
+
+		proc := &customObjectProcessor{
+			client:            bc,
+			entity:            "PersonCustomObjectInstance1",
+			maxDatasetRecords: 50,
+			recordsPerPage:    1,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.Equal(t, len(data), 1)
+		assert.Equal(t, requests, 2)
+	})
+
+	t.Run("returns error when the entity isn't a custom object", func(t *testing.T) {
+		proc := &customObjectProcessor{client: bullhorn.New(""), entity: "Candidate"}
+
+		_, err := proc.QueryData(context.Background())
+		assert.Error(t, err, `unknown custom object "Candidate", expected for example PersonCustomObjectInstance1`)
+	})
+
+	t.Run("returns error when query fails", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.CustomObjectService = mockCustomObjectService{
+			searchFn: func(string, bullhorn.SearchQuery) (*bullhorn.CustomObjectInstances, error) {
+				return nil, errors.New("query custom objects failed")
+			},
+		}
+
+		proc := &customObjectProcessor{
+			client:            bc,
+			entity:            "PersonCustomObjectInstance1",
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		_, err := proc.QueryData(context.Background())
+		assert.Error(t, err, "query custom objects failed")
+	})
+
+	t.Run("errors when invalid field", func(t *testing.T) {
+		specs := []struct {
+			name    string
+			fields  string
+			wantErr string
+		}{
+			{
+				name:    "invalid field name",
+				fields:  "text1,customText1",
+				wantErr: `unknown PersonCustomObjectInstance1 field "customText1", only text0, textBlock0, date0, int0 and float0 are valid`,
+			},
+			{
+				name:    "field under range",
+				fields:  "int0",
+				wantErr: `PersonCustomObjectInstance1 field "int0", is out of range min field number is 1`,
+			},
+			{
+				name:    "field over range",
+				fields:  "date11",
+				wantErr: `PersonCustomObjectInstance1 field "date11", is out of range max field number is 10`,
+			},
+		}
+
+		for _, spec := range specs {
+			t.Run(spec.name, func(t *testing.T) {
+				defer unsetEnv()
+				os.Setenv("PERSONCUSTOMOBJECTINSTANCE1_FIELDS", spec.fields)
+
+				proc := &customObjectProcessor{client: bullhorn.New(""), entity: "PersonCustomObjectInstance1"}
+
+				_, err := proc.QueryData(context.Background())
+				assert.Error(t, err, spec.wantErr)
+			})
+		}
+	})
+}
+
+type mockCustomObjectService struct {
+	searchFn func(string, bullhorn.SearchQuery) (*bullhorn.CustomObjectInstances, error)
+}
+
+func (m mockCustomObjectService) Search(_ context.Context, entity string, query bullhorn.SearchQuery) (*bullhorn.CustomObjectInstances, error) {
+	return m.searchFn(entity, query)
+}
@@ -0,0 +1,99 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"strings"
+)
+
+// fieldTypeSuffixes are the Geckoboard types a custom, nested or built in
+// field can be given with a suffix like customFloat1:money
+var fieldTypeSuffixes = map[string]geckoboard.FieldType{
+	"string":     geckoboard.StringType,
+	"number":     geckoboard.NumberType,
+	"percentage": geckoboard.PercentType,
+	"money":      geckoboard.MoneyType,
+	"datetime":   geckoboard.DatetimeType,
+	"date":       geckoboard.DateType,
+	"duration":   geckoboard.DurationType,
+}
+
+// splitTypeSuffix separates a field from its optional
+// type suffix, so customInt1:duration:minutes returns
+// customInt1 and duration:minutes
+func splitTypeSuffix(field string) (string, string) {
+	parts := strings.SplitN(field, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// parseTypeSuffix returns the Geckoboard type of the suffix, durations
+// can also have a time unit after the type and default to hours
+func parseTypeSuffix(suffix string) (geckoboard.FieldType, geckoboard.TimeUnit, bool) {
+	parts := strings.Split(suffix, ":")
+
+	fieldType, ok := fieldTypeSuffixes[parts[0]]
+	if !ok || len(parts) > 2 {
+		return "", "", false
+	}
+
+	if fieldType != geckoboard.DurationType {
+		if len(parts) > 1 {
+			return "", "", false
+		}
+
+		return fieldType, "", true
+	}
+
+	if len(parts) == 1 {
+		return fieldType, geckoboard.Hours, true
+	}
+
+	timeUnit := geckoboard.TimeUnit(parts[1])
+	switch timeUnit {
+	case geckoboard.Milliseconds, geckoboard.Seconds, geckoboard.Minutes, geckoboard.Hours:
+		return fieldType, timeUnit, true
+	}
+
+	return "", "", false
+}
+
+func containsFieldType(types []geckoboard.FieldType, fieldType geckoboard.FieldType) bool {
+	for _, t := range types {
+		if t == fieldType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// schemaField builds an optional dataset field setting the
+// extra attributes money and duration fields require
+func schemaField(name string, fieldType geckoboard.FieldType, timeUnit geckoboard.TimeUnit) geckoboard.Field {
+	field := geckoboard.Field{
+		Name:     name,
+		Type:     fieldType,
+		Optional: true,
+	}
+
+	switch fieldType {
+	case geckoboard.MoneyType:
+		field.CurrencyCode = currencyCode()
+	case geckoboard.DurationType:
+		field.TimeUnit = timeUnit
+	}
+
+	return field
+}
+
+func dateValue(epoch bullhorn.EpochMilli) *string {
+	if epoch == 0 {
+		return nil
+	}
+
+	return geckoboard.DateValue(epoch.Time())
+}
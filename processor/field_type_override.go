@@ -0,0 +1,139 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// fieldTypeConversions are the types each type of dataset field
+// can be pushed as instead with a suffix in ENTITY_FIELDTYPES
+var fieldTypeConversions = map[geckoboard.FieldType][]geckoboard.FieldType{
+	geckoboard.NumberType:   {geckoboard.NumberType, geckoboard.MoneyType, geckoboard.PercentType, geckoboard.DurationType},
+	geckoboard.PercentType:  {geckoboard.PercentType, geckoboard.NumberType},
+	geckoboard.DurationType: {geckoboard.DurationType, geckoboard.NumberType},
+	geckoboard.MoneyType:    {geckoboard.MoneyType, geckoboard.NumberType},
+	geckoboard.DatetimeType: {geckoboard.DatetimeType, geckoboard.DateType},
+}
+
+// fieldTypeOverride pushes a field of the dataset, such as
+// the built in hours_per_day:duration, as another type
+type fieldTypeOverride struct {
+	datasetField string
+	fieldType    geckoboard.FieldType
+	timeUnit     geckoboard.TimeUnit
+}
+
+type fieldTypeOverrides []fieldTypeOverride
+
+func fetchFieldTypeOverrides(entity string) (fieldTypeOverrides, error) {
+	env := os.Getenv(fmt.Sprintf("%s_FIELDTYPES", strings.ReplaceAll(strings.ToUpper(entity), " ", "")))
+	if env == "" {
+		return nil, nil
+	}
+
+	overrides := fieldTypeOverrides{}
+	for _, f := range strings.Split(env, ",") {
+		field, suffix := splitTypeSuffix(strings.TrimSpace(f))
+
+		fieldType, timeUnit, ok := parseTypeSuffix(suffix)
+		if !ok {
+			return nil, fmt.Errorf("%s field %q has an invalid type %q", entity, field, suffix)
+		}
+
+		overrides = append(overrides, fieldTypeOverride{
+			datasetField: field,
+			fieldType:    fieldType,
+			timeUnit:     timeUnit,
+		})
+	}
+
+	return overrides, nil
+}
+
+// apply changes the type of the overridden fields in the
+// dataset, converting their values in each of the rows
+func (o fieldTypeOverrides) apply(entity string, dataset *geckoboard.Dataset, data geckoboard.Data) error {
+	for _, override := range o {
+		field, ok := dataset.Fields[override.datasetField]
+		if !ok {
+			return fmt.Errorf("%s has no field %q to change the type of", entity, override.datasetField)
+		}
+
+		if !containsFieldType(fieldTypeConversions[field.Type], override.fieldType) {
+			return fmt.Errorf("%s field %q can't be pushed as %s", entity, override.datasetField, override.fieldType)
+		}
+
+		for _, row := range data {
+			if val, found := row[override.datasetField]; found {
+				row[override.datasetField] = override.convert(field, val)
+			}
+		}
+
+		newField := schemaField(field.Name, override.fieldType, override.timeUnit)
+		newField.Optional = field.Optional
+		dataset.Fields[override.datasetField] = newField
+	}
+
+	return nil
+}
+
+// convert returns the value of the field as the type of the override, values
+// which can't be converted are left for the dataset validation to reject
+func (override fieldTypeOverride) convert(field geckoboard.Field, val interface{}) interface{} {
+	val = geckoboard.Indirect(val)
+	if val == nil {
+		return nil
+	}
+
+	if override.fieldType == geckoboard.DateType {
+		s, _ := val.(string)
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return val
+		}
+
+		return geckoboard.DateValue(t)
+	}
+
+	if override.fieldType == geckoboard.DatetimeType {
+		return val
+	}
+
+	n, ok := geckoboard.ToFloat(val)
+	if !ok {
+		return val
+	}
+
+	switch field.Type {
+	case geckoboard.MoneyType:
+		n = n / 100
+	case geckoboard.DurationType:
+		if override.fieldType == geckoboard.DurationType {
+			d := time.Duration(n * float64(timeUnitDuration(field.TimeUnit)))
+			return geckoboard.DurationValue(d, override.timeUnit)
+		}
+	}
+
+	if override.fieldType == geckoboard.MoneyType {
+		return geckoboard.MoneyValue(n)
+	}
+
+	return n
+}
+
+// timeUnitDuration returns how long one of the time unit is
+func timeUnitDuration(unit geckoboard.TimeUnit) time.Duration {
+	switch unit {
+	case geckoboard.Milliseconds:
+		return time.Millisecond
+	case geckoboard.Seconds:
+		return time.Second
+	case geckoboard.Minutes:
+		return time.Minute
+	}
+
+	return time.Hour
+}
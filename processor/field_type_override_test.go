@@ -0,0 +1,160 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFieldTypeOverrides(t *testing.T) {
+	unsetEnv := func() {
+		os.Unsetenv("PLACEMENT_FIELDTYPES")
+	}
+
+	testDataset := func() *geckoboard.Dataset {
+		return &geckoboard.Dataset{
+			Name: "bullhorn-placements",
+			Fields: map[string]geckoboard.Field{
+				"id":             {Name: "ID", Type: geckoboard.StringType},
+				"hours_per_day":  {Name: "Hours per day", Type: geckoboard.NumberType, Optional: true},
+				"salary":         {Name: "Salary", Type: geckoboard.MoneyType, CurrencyCode: "USD", Optional: true},
+				"date_begin":     {Name: "Date Begin", Type: geckoboard.DatetimeType, Optional: true},
+				"time_in_status": {Name: "Time in status", Type: geckoboard.DurationType, TimeUnit: geckoboard.Hours, Optional: true},
+			},
+		}
+	}
+
+	testData := func() geckoboard.Data {
+		return geckoboard.Data{
+			{
+				"id":             "1",
+				"hours_per_day":  7.5,
+				"salary":         3000000,
+				"date_begin":     stringPtr("2022-07-30T08:37:01Z"),
+				"time_in_status": floatPtr(1.5),
+			},
+			{
+				"id":             "2",
+				"hours_per_day":  (*float64)(nil),
+				"salary":         0,
+				"date_begin":     (*string)(nil),
+				"time_in_status": (*float64)(nil),
+			},
+		}
+	}
+
+	t.Run("returns no overrides when not set", func(t *testing.T) {
+		got, err := fetchFieldTypeOverrides("placement")
+		assert.NilError(t, err)
+		assert.Assert(t, got == nil)
+
+		dataset, data := testDataset(), testData()
+		assert.NilError(t, got.apply("placement", dataset, data))
+		assert.DeepEqual(t, dataset, testDataset())
+		assert.DeepEqual(t, data, testData())
+	})
+
+	t.Run("returns the overrides of the entity", func(t *testing.T) {
+		defer unsetEnv()
+		os.Setenv("PLACEMENT_FIELDTYPES", "hours_per_day:duration, date_begin:date,time_in_status:duration:minutes")
+
+		got, err := fetchFieldTypeOverrides("placement")
+		assert.NilError(t, err)
+		assert.Equal(t, len(got), 3)
+		assert.Equal(t, got[0], fieldTypeOverride{datasetField: "hours_per_day", fieldType: geckoboard.DurationType, timeUnit: geckoboard.Hours})
+		assert.Equal(t, got[1], fieldTypeOverride{datasetField: "date_begin", fieldType: geckoboard.DateType})
+		assert.Equal(t, got[2], fieldTypeOverride{datasetField: "time_in_status", fieldType: geckoboard.DurationType, timeUnit: geckoboard.Minutes})
+	})
+
+	t.Run("returns error when the type is invalid", func(t *testing.T) {
+		defer unsetEnv()
+
+		for _, env := range []string{"hours_per_day", "hours_per_day:seconds", "hours_per_day:number:hours"} {
+			os.Setenv("PLACEMENT_FIELDTYPES", env)
+
+			_, err := fetchFieldTypeOverrides("placement")
+			assert.ErrorContains(t, err, `placement field "hours_per_day" has an invalid type`)
+		}
+	})
+
+	t.Run("converts the fields and their values", func(t *testing.T) {
+		overrides := fieldTypeOverrides{
+			{datasetField: "hours_per_day", fieldType: geckoboard.MoneyType},
+			{datasetField: "salary", fieldType: geckoboard.NumberType},
+			{datasetField: "date_begin", fieldType: geckoboard.DateType},
+			{datasetField: "time_in_status", fieldType: geckoboard.DurationType, timeUnit: geckoboard.Minutes},
+		}
+
+		dataset, data := testDataset(), testData()
+		assert.NilError(t, overrides.apply("placement", dataset, data))
+
+		assert.DeepEqual(t, dataset.Fields, map[string]geckoboard.Field{
+			"id":             {Name: "ID", Type: geckoboard.StringType},
+			"hours_per_day":  {Name: "Hours per day", Type: geckoboard.MoneyType, CurrencyCode: "USD", Optional: true},
+			"salary":         {Name: "Salary", Type: geckoboard.NumberType, Optional: true},
+			"date_begin":     {Name: "Date Begin", Type: geckoboard.DateType, Optional: true},
+			"time_in_status": {Name: "Time in status", Type: geckoboard.DurationType, TimeUnit: geckoboard.Minutes, Optional: true},
+		})
+
+		assert.DeepEqual(t, data, geckoboard.Data{
+			{
+				"id":             "1",
+				"hours_per_day":  750,
+				"salary":         30000.0,
+				"date_begin":     stringPtr("2022-07-30"),
+				"time_in_status": 90.0,
+			},
+			{
+				"id":             "2",
+				"hours_per_day":  nil,
+				"salary":         0.0,
+				"date_begin":     nil,
+				"time_in_status": nil,
+			},
+		})
+	})
+
+	t.Run("returns error when the field isn't in the dataset", func(t *testing.T) {
+		overrides := fieldTypeOverrides{{datasetField: "fee", fieldType: geckoboard.MoneyType}}
+
+		err := overrides.apply("placement", testDataset(), testData())
+		assert.Error(t, err, `placement has no field "fee" to change the type of`)
+	})
+
+	t.Run("returns error when the field can't be pushed as the type", func(t *testing.T) {
+		overrides := fieldTypeOverrides{{datasetField: "date_begin", fieldType: geckoboard.NumberType}}
+
+		err := overrides.apply("placement", testDataset(), testData())
+		assert.Error(t, err, `placement field "date_begin" can't be pushed as number`)
+	})
+
+	t.Run("queries the dataset with the overridden types", func(t *testing.T) {
+		defer os.Unsetenv("MOCKMODEL_FIELDTYPES")
+		os.Setenv("MOCKMODEL_FIELDTYPES", "field3:percentage")
+
+		proc, _ := defaultNewProcessor(nil, []datasetProcessor{
+			mockDatasetProcessor{
+				queryDataFn: func() (geckoboard.Data, error) {
+					return geckoboard.Data{{"id": "1", "field3": 44}}, nil
+				},
+				schemaFn: func() *geckoboard.Dataset {
+					return &geckoboard.Dataset{
+						Name: "mock-model",
+						Fields: map[string]geckoboard.Field{
+							"id":     {Name: "ID", Type: geckoboard.StringType},
+							"field3": {Name: "Field 3", Type: geckoboard.NumberType},
+						},
+					}
+				},
+			},
+		})
+
+		dataset, data, err := proc.Query(context.Background(), "mock-model")
+		assert.NilError(t, err)
+		assert.Equal(t, dataset.Fields["field3"].Type, geckoboard.PercentType)
+		assert.DeepEqual(t, data, geckoboard.Data{{"id": "1", "field3": 44.0}})
+	})
+}
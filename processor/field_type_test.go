@@ -0,0 +1,46 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseTypeSuffix(t *testing.T) {
+	specs := []struct {
+		in           string
+		wantType     geckoboard.FieldType
+		wantTimeUnit geckoboard.TimeUnit
+		wantOK       bool
+	}{
+		{in: "string", wantType: geckoboard.StringType, wantOK: true},
+		{in: "money", wantType: geckoboard.MoneyType, wantOK: true},
+		{in: "date", wantType: geckoboard.DateType, wantOK: true},
+		{in: "duration", wantType: geckoboard.DurationType, wantTimeUnit: geckoboard.Hours, wantOK: true},
+		{in: "duration:seconds", wantType: geckoboard.DurationType, wantTimeUnit: geckoboard.Seconds, wantOK: true},
+		{in: "duration:days"},
+		{in: "duration:seconds:extra"},
+		{in: "money:hours"},
+		{in: "currency"},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.in, func(t *testing.T) {
+			gotType, gotTimeUnit, gotOK := parseTypeSuffix(spec.in)
+			assert.Equal(t, gotType, spec.wantType)
+			assert.Equal(t, gotTimeUnit, spec.wantTimeUnit)
+			assert.Equal(t, gotOK, spec.wantOK)
+		})
+	}
+}
+
+func TestSplitTypeSuffix(t *testing.T) {
+	field, suffix := splitTypeSuffix("customInt1:duration:minutes")
+	assert.Equal(t, field, "customInt1")
+	assert.Equal(t, suffix, "duration:minutes")
+
+	field, suffix = splitTypeSuffix("customInt1")
+	assert.Equal(t, field, "customInt1")
+	assert.Equal(t, suffix, "")
+}
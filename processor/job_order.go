@@ -4,30 +4,53 @@ import (
 	"bullhorn-to-dataset/bullhorn"
 	"bullhorn-to-dataset/geckoboard"
 	"context"
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
 )
 
+var (
+	jobOrderCustomFieldRules = map[string]int{
+		"Date":      3,
+		"Text":      20,
+		"Float":     3,
+		"Int":       3,
+		"TextBlock": 5,
+	}
+)
+
 type jobOrderProcessor struct {
 	client *bullhorn.Client
 
 	maxDatasetRecords int
 	ordersPerPage     int
+	customFields      customFields
+	nestedFields      nestedFields
+	departments       *ownerDepartmentCache
 }
 
-func (j jobOrderProcessor) String() string {
+func (jobOrderProcessor) String() string {
 	return "job order"
 }
 
-func (j jobOrderProcessor) QueryData(ctx context.Context) (geckoboard.Data, error) {
+func (j *jobOrderProcessor) QueryData(ctx context.Context) (geckoboard.Data, error) {
+	if err := j.customFields.fetchAndValidateCustomFields(j.String(), jobOrderCustomFieldRules); err != nil {
+		return nil, err
+	}
+
+	if err := j.nestedFields.fetchAndValidateNestedFields(j.String()); err != nil {
+		return nil, err
+	}
+
 	jobOrders, err := j.queryJobOrders(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Println("Queried", len(jobOrders), "job orders")
+	departments, err := j.departments.get(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	maxIndex := int(math.Min(float64(len(jobOrders)), float64(j.maxDatasetRecords)))
 	latestOrders := jobOrders[0:maxIndex]
@@ -48,85 +71,103 @@ func (j jobOrderProcessor) QueryData(ctx context.Context) (geckoboard.Data, erro
 			"open":               strings.ToUpper(strconv.FormatBool(o.IsOpen)),
 		}
 
+		j.customFields.extractCustomFieldData(o, entry)
+		j.nestedFields.extractNestedFieldData(o.Raw, entry)
+		departments.extractOwnerDepartmentData(o.Owner, entry)
 		data = append(data, entry)
 	}
 
 	return data, nil
 }
 
-func (j jobOrderProcessor) Schema() *geckoboard.Dataset {
-	return &geckoboard.Dataset{
-		Name: "bullhorn-joborders",
-		Fields: map[string]geckoboard.Field{
-			"id": {
-				Name:     "ID",
-				Type:     geckoboard.StringType,
-				Optional: false,
-			},
-			"date_added": {
-				Name: "Date added", Type: geckoboard.DatetimeType,
-				Optional: true,
-			},
-			"date_closed": {
-				Name: "Closed at", Type: geckoboard.DatetimeType,
-				Optional: true,
-			},
-			"date_ended": {
-				Name: "Ended at", Type: geckoboard.DatetimeType,
-				Optional: true,
-			},
-			"title": {
-				Name:     "Title",
-				Type:     geckoboard.StringType,
-				Optional: true,
-			},
-			"status": {
-				Name:     "Status",
-				Type:     geckoboard.StringType,
-				Optional: true,
-			},
-			"categories": {
-				Name:     "Categories",
-				Type:     geckoboard.StringType,
-				Optional: true,
-			},
-			"employment_type": {
-				Name:     "Employment type",
-				Type:     geckoboard.StringType,
-				Optional: true,
-			},
-			"owner": {
-				Name:     "Owner",
-				Type:     geckoboard.StringType,
-				Optional: true,
-			},
-			"client_corporation": {
-				Name:     "Client corporation",
-				Type:     geckoboard.StringType,
-				Optional: true,
-			},
-			"open": {
-				Name:     "Open",
-				Type:     geckoboard.StringType,
-				Optional: true,
-			},
+func (j *jobOrderProcessor) Schema() *geckoboard.Dataset {
+	datasetFields := map[string]geckoboard.Field{
+		"id": {
+			Name:     "ID",
+			Type:     geckoboard.StringType,
+			Optional: false,
+		},
+		"date_added": {
+			Name: "Date added", Type: geckoboard.DatetimeType,
+			Optional: true,
+		},
+		"date_closed": {
+			Name: "Closed at", Type: geckoboard.DatetimeType,
+			Optional: true,
+		},
+		"date_ended": {
+			Name: "Ended at", Type: geckoboard.DatetimeType,
+			Optional: true,
+		},
+		"title": {
+			Name:     "Title",
+			Type:     geckoboard.StringType,
+			Optional: true,
 		},
+		"status": {
+			Name:     "Status",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+		"categories": {
+			Name:     "Categories",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+		"employment_type": {
+			Name:     "Employment type",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+		"owner": {
+			Name:     "Owner",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+		"client_corporation": {
+			Name:     "Client corporation",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+		"open": {
+			Name:     "Open",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+	}
+
+	j.customFields.extractCustomFieldsForSchema(datasetFields)
+	j.nestedFields.extractNestedFieldsForSchema(datasetFields)
+	extractOwnerDepartmentForSchema(datasetFields)
+
+	return &geckoboard.Dataset{
+		Name:     "bullhorn-joborders",
+		Fields:   datasetFields,
 		UniqueBy: []string{"id"},
 	}
 }
 
-func (j jobOrderProcessor) queryJobOrders(ctx context.Context) ([]bullhorn.JobOrder, error) {
+func (j *jobOrderProcessor) queryJobOrders(ctx context.Context) ([]bullhorn.JobOrder, error) {
 	var jobOrders []bullhorn.JobOrder
 
+	queryFields := []string{
+		"id", "dateAdded", "dateClosed", "dateEnd", "status",
+		"categories", "employmentType", "title", "owner",
+		"clientCorporation", "isOpen",
+	}
+
+	for _, f := range j.customFields {
+		queryFields = append(queryFields, f.sanitized)
+	}
+
+	queryFields = append(queryFields, j.nestedFields.queryFields()...)
+
 	query := bullhorn.SearchQuery{
-		Fields: []string{
-			"id", "dateAdded", "dateClosed", "dateEnd", "status",
-			"categories", "employmentType", "title", "owner",
-			"clientCorporation", "isOpen",
-		},
-		Where: "isDeleted=false",
-		Start: 0,
-		Count: j.ordersPerPage,
+		Fields:     queryFields,
+		Where:      "isDeleted=false",
+		Start:      0,
+		Count:      j.ordersPerPage,
+		IncludeRaw: len(j.nestedFields) > 0,
 	}
 
 	for {
@@ -5,6 +5,8 @@ import (
 	"bullhorn-to-dataset/geckoboard"
 	"context"
 	"errors"
+	"os"
+	"strings"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -72,6 +74,9 @@ var (
 			Client: bullhorn.NestedEntity{
 				Name: "Los Pollos Hermanos",
 			},
+			CustomDate2:  1653214787000,
+			CustomText2:  "text2",
+			CustomFloat3: 3,
 			Categories: bullhorn.Categories{
 				Data: []bullhorn.NestedEntity{
 					{Name: "Category B"},
@@ -94,6 +99,8 @@ var (
 			Client: bullhorn.NestedEntity{
 				Name: "Hamlin Hamlin McGill",
 			},
+			CustomText2:  "text22",
+			CustomFloat3: 23,
 		},
 		{
 			ID:             3333,
@@ -109,6 +116,7 @@ var (
 			Client: bullhorn.NestedEntity{
 				Name: "JMM",
 			},
+			CustomDate2: 1653204787000,
 			Categories: bullhorn.Categories{
 				Data: []bullhorn.NestedEntity{
 					{Name: "Category C"},
@@ -124,7 +132,7 @@ func TestJobOrder_String(t *testing.T) {
 }
 
 func TestJobOrder_Schema(t *testing.T) {
-	got := jobOrderProcessor{}.Schema()
+	got := (&jobOrderProcessor{}).Schema()
 	want := &geckoboard.Dataset{
 		Name: "bullhorn-joborders",
 		Fields: map[string]geckoboard.Field{
@@ -185,6 +193,36 @@ func TestJobOrder_Schema(t *testing.T) {
 	}
 
 	assert.DeepEqual(t, got, want)
+
+	t.Run("builds schema with custom fields", func(t *testing.T) {
+		proc := &jobOrderProcessor{
+			customFields: []customField{
+				{
+					datasetField: "custom_text_1",
+					fieldType:    "Text",
+					displayName:  "custom Text 1",
+				},
+				{
+					datasetField: "custom_float_2",
+					fieldType:    "Float",
+					displayName:  "custom Float 2",
+				},
+			},
+		}
+
+		got := proc.Schema()
+		assert.DeepEqual(t, got.Fields["custom_text_1"], geckoboard.Field{
+			Name:     "custom Text 1",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		})
+		assert.DeepEqual(t, got.Fields["custom_float_2"], geckoboard.Field{
+			Name:     "custom Float 2",
+			Type:     geckoboard.NumberType,
+			Optional: true,
+		})
+		assert.Equal(t, len(got.Fields), len(want.Fields)+2)
+	})
 }
 
 func TestJobOrder_QueryData(t *testing.T) {
@@ -298,6 +336,143 @@ func TestJobOrder_QueryData(t *testing.T) {
 		_, err := proc.QueryData(context.Background())
 		assert.Error(t, err, "query job orders failed")
 	})
+
+	t.Run("custom fields", func(t *testing.T) {
+		unsetEnv := func() {
+			os.Unsetenv("JOBORDER_CUSTOMFIELDS")
+		}
+		setEnv := func(val []string) {
+			// Add space between to ensure we sanitize
+			os.Setenv("JOBORDER_CUSTOMFIELDS", strings.Join(val, " , "))
+		}
+
+		t.Run("queries extra custom fields and sets the data in the dataset", func(t *testing.T) {
+			defer unsetEnv()
+
+			fields := []string{"customDate2", "customText2", "customFloat3"}
+			bc := bullhorn.New("")
+			bc.JobOrderService = mockJobOrderService{
+				searchFn: func(got bullhorn.SearchQuery) (*bullhorn.JobOrders, error) {
+					want := bullhorn.SearchQuery{
+						Fields: append(wantJobOrderFields, fields...),
+						Where:  "isDeleted=false",
+						Count:  200,
+					}
+
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.JobOrders{
+						Items: testJobOrders,
+					}, nil
+				},
+			}
+
+			proc := jobOrderProcessor{client: bc, maxDatasetRecords: 50, ordersPerPage: 200}
+			setEnv(fields)
+
+			data, err := proc.QueryData(context.Background())
+			assert.NilError(t, err)
+			assert.DeepEqual(t, data, geckoboard.Data{
+				{
+					"categories":         "Category A ; Category B",
+					"client_corporation": "Los Pollos Hermanos",
+					"custom_date_2":      stringPtr("2022-05-22T10:19:47Z"),
+					"custom_float_3":     float64(3),
+					"custom_text_2":      "text2",
+					"date_added":         stringPtr("2022-05-22T10:19:47Z"),
+					"date_closed":        (*string)(nil),
+					"date_ended":         (*string)(nil),
+					"employment_type":    "Contract",
+					"id":                 "4345",
+					"open":               "FALSE",
+					"owner":              stringPtr("Gustavo Fring"),
+					"status":             "Accepting Candidates",
+					"title":              "Automation engineer",
+				},
+				{
+					"categories":         "(not set)",
+					"client_corporation": "Hamlin Hamlin McGill",
+					"custom_date_2":      (*string)(nil),
+					"custom_float_3":     float64(23),
+					"custom_text_2":      "text22",
+					"date_added":         stringPtr("2022-05-22T07:33:07Z"),
+					"date_closed":        stringPtr("2022-05-22T10:19:47Z"),
+					"date_ended":         stringPtr("2022-05-22T10:23:06Z"),
+					"employment_type":    "Permanent",
+					"id":                 "5555",
+					"open":               "FALSE",
+					"owner":              stringPtr("Kim Wexler"),
+					"status":             "Closed",
+					"title":              "CEO",
+				},
+				{
+					"categories":         "Category C",
+					"client_corporation": "JMM",
+					"custom_date_2":      stringPtr("2022-05-22T07:33:07Z"),
+					"custom_float_3":     float64(0),
+					"custom_text_2":      "",
+					"date_added":         stringPtr("2022-05-22T07:33:07Z"),
+					"date_closed":        stringPtr("2022-05-22T10:19:47Z"),
+					"date_ended":         stringPtr("2022-05-22T10:23:06Z"),
+					"employment_type":    "Contract",
+					"id":                 "3333",
+					"open":               "FALSE",
+					"owner":              stringPtr("Saul"),
+					"status":             "Closed",
+					"title":              "Support role",
+				},
+			})
+		})
+
+		t.Run("errors when invalid custom field", func(t *testing.T) {
+			specs := []struct {
+				name    string
+				fields  []string
+				wantErr string
+			}{
+				{
+					name:    "invalid custom field name",
+					fields:  []string{"customDate2", "customField2"},
+					wantErr: `unknown job order field "customField2", only customDate0, customText0, customTextBlock0, customFloat0 and customInt0 are valid`,
+				},
+				{
+					name:    "custom date field over range",
+					fields:  []string{"customDate3", "customDate4"},
+					wantErr: `job order field "customDate4", is out of range max field number is 3`,
+				},
+				{
+					name:    "custom text field over range",
+					fields:  []string{"customText20", "customText21"},
+					wantErr: `job order field "customText21", is out of range max field number is 20`,
+				},
+				{
+					name:    "custom float field over range",
+					fields:  []string{"customFloat3", "customFloat4"},
+					wantErr: `job order field "customFloat4", is out of range max field number is 3`,
+				},
+				{
+					name:    "custom text field under range",
+					fields:  []string{"customText0"},
+					wantErr: `job order field "customText0", is out of range min field number is 1`,
+				},
+			}
+
+			for _, spec := range specs {
+				t.Run(spec.name, func(t *testing.T) {
+					defer unsetEnv()
+
+					proc := jobOrderProcessor{
+						client:            bullhorn.New(""),
+						maxDatasetRecords: 50,
+						ordersPerPage:     200,
+					}
+
+					setEnv(spec.fields)
+					_, gotErr := proc.QueryData(context.Background())
+					assert.ErrorContains(t, gotErr, spec.wantErr)
+				})
+			}
+		})
+	})
 }
 
 // Mocks for the clients
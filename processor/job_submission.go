@@ -11,8 +11,10 @@ import (
 
 var (
 	jobSubsCustomFieldRules = map[string]int{
-		"Date":  5,
-		"Float": 5,
+		"Date":      5,
+		"Float":     5,
+		"Int":       5,
+		"TextBlock": 5,
 	}
 )
 
@@ -22,6 +24,8 @@ type jobSubmissionProcessor struct {
 	maxDatasetRecords int
 	recordsPerPage    int
 	customFields      customFields
+	nestedFields      nestedFields
+	departments       *ownerDepartmentCache
 }
 
 func (jobSubmissionProcessor) String() string {
@@ -33,21 +37,30 @@ func (p *jobSubmissionProcessor) QueryData(ctx context.Context) (geckoboard.Data
 		return nil, err
 	}
 
+	if err := p.nestedFields.fetchAndValidateNestedFields(p.String()); err != nil {
+		return nil, err
+	}
+
 	submissions, err := p.queryJobSubmissions(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Println("Queried", len(submissions), "job submissions")
+	departments, err := p.departments.get(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	maxIndex := int(math.Min(float64(len(submissions)), float64(p.maxDatasetRecords)))
 	latestJobSubmissions := submissions[0:maxIndex]
 
 	data := geckoboard.Data{}
 	for _, js := range latestJobSubmissions {
+		var owner bullhorn.Person
 		var ownerName string
 		if len(js.Owners.Items) > 0 {
-			ownerName = *js.Owners.Items[0].FullName()
+			owner = js.Owners.Items[0]
+			ownerName = *owner.FullName()
 		}
 
 		entry := geckoboard.DataRow{
@@ -63,6 +76,8 @@ func (p *jobSubmissionProcessor) QueryData(ctx context.Context) (geckoboard.Data
 		}
 
 		p.customFields.extractCustomFieldData(js, entry)
+		p.nestedFields.extractNestedFieldData(js.Raw, entry)
+		departments.extractOwnerDepartmentData(owner, entry)
 		data = append(data, entry)
 	}
 
@@ -114,6 +129,8 @@ func (p *jobSubmissionProcessor) Schema() *geckoboard.Dataset {
 	}
 
 	p.customFields.extractCustomFieldsForSchema(datasetFields)
+	p.nestedFields.extractNestedFieldsForSchema(datasetFields)
+	extractOwnerDepartmentForSchema(datasetFields)
 
 	return &geckoboard.Dataset{
 		Name:     "bullhorn-job-submissions",
@@ -134,11 +151,14 @@ func (p *jobSubmissionProcessor) queryJobSubmissions(ctx context.Context) ([]bul
 		queryFields = append(queryFields, f.sanitized)
 	}
 
+	queryFields = append(queryFields, p.nestedFields.queryFields()...)
+
 	query := bullhorn.SearchQuery{
-		Fields: queryFields,
-		Where:  "isDeleted=false",
-		Start:  0,
-		Count:  p.recordsPerPage,
+		Fields:     queryFields,
+		Where:      "isDeleted=false",
+		Start:      0,
+		Count:      p.recordsPerPage,
+		IncludeRaw: len(p.nestedFields) > 0,
 	}
 
 	for {
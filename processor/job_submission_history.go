@@ -0,0 +1,237 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type jobSubmissionHistoryProcessor struct {
+	client *bullhorn.Client
+
+	maxDatasetRecords int
+	recordsPerPage    int
+}
+
+func (jobSubmissionHistoryProcessor) String() string {
+	return "job submission history"
+}
+
+// historyBatchSize is how many submissions the history
+// before the queried window is looked up for at once
+const historyBatchSize = 100
+
+// QueryData returns a row for each status transition of a job submission. The
+// history is ordered by submission and date so the previous status, and how long
+// the submission was in it, can be worked out from the entry before it
+func (p *jobSubmissionHistoryProcessor) QueryData(ctx context.Context) (geckoboard.Data, error) {
+	histories, complete, err := p.queryJobSubmissionHistories(ctx, "id>0", p.maxDatasetRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIndex := int(math.Min(float64(len(histories)), float64(p.maxDatasetRecords)))
+	latestHistories := histories[0:maxIndex]
+
+	// When only the latest entries were queried the entry before the
+	// oldest one of each submission can be outside of them
+	previous := map[int]bullhorn.JobSubmissionHistory{}
+	if !complete || len(histories) > maxIndex {
+		previous, err = p.queryPreviousHistories(ctx, latestHistories)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(latestHistories, func(i, j int) bool {
+		a, b := latestHistories[i], latestHistories[j]
+		if a.JobSubmission.ID != b.JobSubmission.ID {
+			return a.JobSubmission.ID < b.JobSubmission.ID
+		}
+
+		return historyBefore(a, b)
+	})
+
+	data := geckoboard.Data{}
+	for i, h := range latestHistories {
+		var fromStatus *string
+		var hoursInStatus *float64
+
+		prev, found := previous[h.JobSubmission.ID]
+		if i > 0 && latestHistories[i-1].JobSubmission.ID == h.JobSubmission.ID {
+			prev, found = latestHistories[i-1], true
+		}
+
+		if found {
+			fromStatus = valueOrNil(prev.Status)
+
+			hours := geckoboard.DurationValue(h.DateAdded.Time().Sub(prev.DateAdded.Time()), geckoboard.Hours)
+			hours = math.Round(hours*100) / 100
+			hoursInStatus = &hours
+		}
+
+		entry := geckoboard.DataRow{
+			"id":                       strconv.Itoa(h.ID),
+			"submission_id":            strconv.Itoa(h.JobSubmission.ID),
+			"from_status":              fromStatus,
+			"to_status":                h.Status,
+			"transitioned_at":          valueOrNil(h.DateAdded.String()),
+			"hours_in_previous_status": hoursInStatus,
+			"modifying_user":           h.ModifyingUser.FullName(),
+		}
+
+		data = append(data, entry)
+	}
+
+	return data, nil
+}
+
+// queryPreviousHistories returns the entry before the oldest of the
+// histories for each of their submissions which has one
+func (p *jobSubmissionHistoryProcessor) queryPreviousHistories(ctx context.Context, histories []bullhorn.JobSubmissionHistory) (map[int]bullhorn.JobSubmissionHistory, error) {
+	previous := map[int]bullhorn.JobSubmissionHistory{}
+	if len(histories) == 0 {
+		return previous, nil
+	}
+
+	oldest := map[int]bullhorn.JobSubmissionHistory{}
+	for _, h := range histories {
+		o, found := oldest[h.JobSubmission.ID]
+		if !found || historyBefore(h, o) {
+			oldest[h.JobSubmission.ID] = h
+		}
+	}
+
+	ids := make([]int, 0, len(oldest))
+	for id := range oldest {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for start := 0; start < len(ids); start += historyBatchSize {
+		end := int(math.Min(float64(len(ids)), float64(start+historyBatchSize)))
+
+		// The entry before each submission's oldest was added no later than
+		// it, so the batch is queried up to the newest of their oldest entries
+		// and the entries after a submission's own oldest are skipped below
+		batch := make([]string, 0, end-start)
+		var cutoff bullhorn.EpochMilli
+		for _, id := range ids[start:end] {
+			batch = append(batch, strconv.Itoa(id))
+			if oldest[id].DateAdded > cutoff {
+				cutoff = oldest[id].DateAdded
+			}
+		}
+
+		where := fmt.Sprintf("jobSubmission.id IN (%s) AND dateAdded<=%d", strings.Join(batch, ","), cutoff)
+		earlier, _, err := p.queryJobSubmissionHistories(ctx, where, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, h := range earlier {
+			o, found := oldest[h.JobSubmission.ID]
+			if !found || !historyBefore(h, o) {
+				continue
+			}
+
+			prev, found := previous[h.JobSubmission.ID]
+			if !found || historyBefore(prev, h) {
+				previous[h.JobSubmission.ID] = h
+			}
+		}
+	}
+
+	return previous, nil
+}
+
+// historyBefore reports whether a was added before b, entries added at
+// the same time are ordered by their id
+func historyBefore(a, b bullhorn.JobSubmissionHistory) bool {
+	if a.DateAdded != b.DateAdded {
+		return a.DateAdded < b.DateAdded
+	}
+
+	return a.ID < b.ID
+}
+
+func (p *jobSubmissionHistoryProcessor) Schema() *geckoboard.Dataset {
+	return &geckoboard.Dataset{
+		Name: "bullhorn-job-submission-history",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"submission_id": {
+				Name:     "Submission ID",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"from_status": {
+				Name:     "From status",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"to_status": {
+				Name:     "To status",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"transitioned_at": {
+				Name: "Transitioned at", Type: geckoboard.DatetimeType,
+				Optional: true,
+			},
+			"hours_in_previous_status": {
+				Name:     "Hours in previous status",
+				Type:     geckoboard.DurationType,
+				TimeUnit: geckoboard.Hours,
+				Optional: true,
+			},
+			"modifying_user": {
+				Name:     "Modifying user",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+		},
+		UniqueBy: []string{"id"},
+	}
+}
+
+// queryJobSubmissionHistories pages through the histories matching where,
+// stopping once there are at least max of them unless max is zero.
+// complete is false when there might be more histories than returned
+func (p *jobSubmissionHistoryProcessor) queryJobSubmissionHistories(ctx context.Context, where string, max int) (histories []bullhorn.JobSubmissionHistory, complete bool, err error) {
+	query := bullhorn.SearchQuery{
+		Fields: []string{
+			"id", "dateAdded", "jobSubmission", "modifyingUser", "status",
+		},
+		Where: where,
+		Start: 0,
+		Count: p.recordsPerPage,
+	}
+
+	for {
+		hs, err := p.client.JobSubmissionHistoryService.Search(ctx, query)
+		if err != nil {
+			return nil, false, err
+		}
+
+		histories = append(histories, hs.Items...)
+		if len(hs.Items) < query.Count {
+			return histories, true, nil
+		}
+
+		if max > 0 && len(histories) >= max {
+			return histories, false, nil
+		}
+
+		query.Start = query.Count + query.Start
+	}
+}
@@ -0,0 +1,375 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var (
+	wantJobSubmissionHistoryFields = []string{
+		"id", "dateAdded", "jobSubmission", "modifyingUser", "status",
+	}
+
+	wantJobSubmissionHistoryData = geckoboard.Data{
+		{
+			"id":                       "1",
+			"submission_id":            "5",
+			"from_status":              (*string)(nil),
+			"to_status":                "Submitted",
+			"transitioned_at":          stringPtr("2022-07-30T08:37:01Z"),
+			"hours_in_previous_status": (*float64)(nil),
+			"modifying_user":           stringPtr("User A"),
+		},
+		{
+			"id":                       "3",
+			"submission_id":            "5",
+			"from_status":              stringPtr("Submitted"),
+			"to_status":                "Interview Scheduled",
+			"transitioned_at":          stringPtr("2022-07-30T14:10:21Z"),
+			"hours_in_previous_status": floatPtr(5.56),
+			"modifying_user":           stringPtr("User B"),
+		},
+		{
+			"id":                       "2",
+			"submission_id":            "7",
+			"from_status":              (*string)(nil),
+			"to_status":                "Submitted",
+			"transitioned_at":          stringPtr("2022-07-30T11:23:41Z"),
+			"hours_in_previous_status": (*float64)(nil),
+			"modifying_user":           (*string)(nil),
+		},
+	}
+
+	// Ordered by latest first as returned by Bullhorn
+	testJobSubmissionHistories = []bullhorn.JobSubmissionHistory{
+		{
+			ID:            3,
+			DateAdded:     1659190221000,
+			JobSubmission: bullhorn.NestedEntity{ID: 5},
+			ModifyingUser: bullhorn.Person{FirstName: "User", LastName: "B"},
+			Status:        "Interview Scheduled",
+		},
+		{
+			ID:            2,
+			DateAdded:     1659180221000,
+			JobSubmission: bullhorn.NestedEntity{ID: 7},
+			Status:        "Submitted",
+		},
+		{
+			ID:            1,
+			DateAdded:     1659170221000,
+			JobSubmission: bullhorn.NestedEntity{ID: 5},
+			ModifyingUser: bullhorn.Person{FirstName: "User", LastName: "A"},
+			Status:        "Submitted",
+		},
+	}
+)
+
+func TestJobSubmissionHistory_String(t *testing.T) {
+	p := jobSubmissionHistoryProcessor{}
+	assert.Equal(t, p.String(), "job submission history")
+}
+
+func TestJobSubmissionHistory_Schema(t *testing.T) {
+	got := (&jobSubmissionHistoryProcessor{}).Schema()
+	want := &geckoboard.Dataset{
+		Name: "bullhorn-job-submission-history",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"submission_id": {
+				Name:     "Submission ID",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"from_status": {
+				Name:     "From status",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"to_status": {
+				Name:     "To status",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"transitioned_at": {
+				Name: "Transitioned at", Type: geckoboard.DatetimeType,
+				Optional: true,
+			},
+			"hours_in_previous_status": {
+				Name:     "Hours in previous status",
+				Type:     geckoboard.DurationType,
+				TimeUnit: geckoboard.Hours,
+				Optional: true,
+			},
+			"modifying_user": {
+				Name:     "Modifying user",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+		},
+		UniqueBy: []string{"id"},
+	}
+
+	assert.DeepEqual(t, got, want)
+}
+
+func TestJobSubmissionHistory_QueryData(t *testing.T) {
+	t.Run("returns a row per status transition", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.JobSubmissionHistoryService = newJobSubmissionHistoryService(t, testJobSubmissionHistories)
+
+		proc := jobSubmissionHistoryProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantJobSubmissionHistoryData)
+	})
+
+	t.Run("paginates until records are less than the count", func(t *testing.T) {
+		bullhornRequests := 0
+
+		bc := bullhorn.New("")
+		bc.JobSubmissionHistoryService = mockJobSubmissionHistoryService{
+			searchFn: func(got bullhorn.SearchQuery) (*bullhorn.JobSubmissionHistories, error) {
+				bullhornRequests += 1
+				want := bullhorn.SearchQuery{
+					Fields: wantJobSubmissionHistoryFields,
+					Where:  "id>0",
+					Count:  2,
+				}
+
+				switch bullhornRequests {
+				case 1:
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.JobSubmissionHistories{
+						Items: testJobSubmissionHistories[:2],
+					}, nil
+				case 2:
+					want.Start = 2
+					assert.DeepEqual(t, got, want)
+
+					return &bullhorn.JobSubmissionHistories{
+						Items: testJobSubmissionHistories[2:],
+					}, nil
+				}
+
+				return nil, errors.New("shouldn't have got here")
+			},
+		}
+
+		proc := jobSubmissionHistoryProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    2,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantJobSubmissionHistoryData)
+	})
+
+	t.Run("queries the entry before the oldest of each submission when the latest records are cut off", func(t *testing.T) {
+		bullhornRequests := 0
+
+		bc := bullhorn.New("")
+		bc.JobSubmissionHistoryService = mockJobSubmissionHistoryService{
+			searchFn: func(got bullhorn.SearchQuery) (*bullhorn.JobSubmissionHistories, error) {
+				bullhornRequests += 1
+
+				switch bullhornRequests {
+				case 1:
+					assert.DeepEqual(t, got, bullhorn.SearchQuery{
+						Fields: wantJobSubmissionHistoryFields,
+						Where:  "id>0",
+						Count:  2,
+					})
+
+					return &bullhorn.JobSubmissionHistories{
+						Items: testJobSubmissionHistories[:2],
+					}, nil
+				case 2:
+					assert.DeepEqual(t, got, bullhorn.SearchQuery{
+						Fields: wantJobSubmissionHistoryFields,
+						Where:  "jobSubmission.id IN (5,7) AND dateAdded<=1659190221000",
+						Count:  2,
+					})
+
+					return &bullhorn.JobSubmissionHistories{
+						Items: testJobSubmissionHistories[2:],
+					}, nil
+				}
+
+				return nil, errors.New("shouldn't have got here")
+			},
+		}
+
+		proc := jobSubmissionHistoryProcessor{
+			client:            bc,
+			maxDatasetRecords: 2,
+			recordsPerPage:    2,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantJobSubmissionHistoryData[1:])
+		assert.Equal(t, bullhornRequests, 2)
+	})
+
+	t.Run("queries up to the oldest entry of each submission when their oldest dates differ", func(t *testing.T) {
+		bullhornRequests := 0
+
+		// Added after the oldest entry of submission 7 but before the oldest of submission 5
+		earlier := bullhorn.JobSubmissionHistory{
+			ID:            4,
+			DateAdded:     1659185221000,
+			JobSubmission: bullhorn.NestedEntity{ID: 5},
+			Status:        "Submitted",
+		}
+
+		bc := bullhorn.New("")
+		bc.JobSubmissionHistoryService = mockJobSubmissionHistoryService{
+			searchFn: func(got bullhorn.SearchQuery) (*bullhorn.JobSubmissionHistories, error) {
+				bullhornRequests += 1
+
+				switch bullhornRequests {
+				case 1:
+					return &bullhorn.JobSubmissionHistories{
+						Items: testJobSubmissionHistories[:2],
+					}, nil
+				case 2:
+					assert.Equal(t, got.Where, "jobSubmission.id IN (5,7) AND dateAdded<=1659190221000")
+
+					return &bullhorn.JobSubmissionHistories{
+						Items: []bullhorn.JobSubmissionHistory{earlier},
+					}, nil
+				}
+
+				return nil, errors.New("shouldn't have got here")
+			},
+		}
+
+		proc := jobSubmissionHistoryProcessor{
+			client:            bc,
+			maxDatasetRecords: 2,
+			recordsPerPage:    2,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, geckoboard.Data{
+			{
+				"id":                       "3",
+				"submission_id":            "5",
+				"from_status":              stringPtr("Submitted"),
+				"to_status":                "Interview Scheduled",
+				"transitioned_at":          stringPtr("2022-07-30T14:10:21Z"),
+				"hours_in_previous_status": floatPtr(1.39),
+				"modifying_user":           stringPtr("User B"),
+			},
+			wantJobSubmissionHistoryData[2],
+		})
+		assert.Equal(t, bullhornRequests, 2)
+	})
+
+	t.Run("returns error when querying the earlier history fails", func(t *testing.T) {
+		bullhornRequests := 0
+
+		bc := bullhorn.New("")
+		bc.JobSubmissionHistoryService = mockJobSubmissionHistoryService{
+			searchFn: func(bullhorn.SearchQuery) (*bullhorn.JobSubmissionHistories, error) {
+				bullhornRequests += 1
+				if bullhornRequests == 1 {
+					return &bullhorn.JobSubmissionHistories{
+						Items: testJobSubmissionHistories[:2],
+					}, nil
+				}
+
+				return nil, errors.New("query earlier histories failed")
+			},
+		}
+
+		proc := jobSubmissionHistoryProcessor{
+			client:            bc,
+			maxDatasetRecords: 2,
+			recordsPerPage:    2,
+		}
+
+		_, err := proc.QueryData(context.Background())
+		assert.Error(t, err, "query earlier histories failed")
+	})
+
+	t.Run("returns empty data array when no history records", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.JobSubmissionHistoryService = newJobSubmissionHistoryService(t, []bullhorn.JobSubmissionHistory{})
+
+		proc := jobSubmissionHistoryProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, geckoboard.Data{})
+	})
+
+	t.Run("returns error when history query fails", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.JobSubmissionHistoryService = mockJobSubmissionHistoryService{
+			searchFn: func(bullhorn.SearchQuery) (*bullhorn.JobSubmissionHistories, error) {
+				return nil, errors.New("query histories failed")
+			},
+		}
+
+		proc := jobSubmissionHistoryProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		_, err := proc.QueryData(context.Background())
+		assert.Error(t, err, "query histories failed")
+	})
+}
+
+type mockJobSubmissionHistoryService struct {
+	searchFn func(bullhorn.SearchQuery) (*bullhorn.JobSubmissionHistories, error)
+}
+
+func newJobSubmissionHistoryService(t *testing.T, recs []bullhorn.JobSubmissionHistory) mockJobSubmissionHistoryService {
+	return mockJobSubmissionHistoryService{
+		searchFn: func(got bullhorn.SearchQuery) (*bullhorn.JobSubmissionHistories, error) {
+			want := bullhorn.SearchQuery{
+				Fields: wantJobSubmissionHistoryFields,
+				Where:  "id>0",
+				Count:  200,
+			}
+
+			assert.DeepEqual(t, got, want)
+			return &bullhorn.JobSubmissionHistories{
+				Items: recs,
+			}, nil
+		},
+	}
+}
+
+func (m mockJobSubmissionHistoryService) Search(_ context.Context, query bullhorn.SearchQuery) (*bullhorn.JobSubmissionHistories, error) {
+	return m.searchFn(query)
+}
+
+func floatPtr(val float64) *float64 {
+	return &val
+}
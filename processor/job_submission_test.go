@@ -451,7 +451,7 @@ func TestJobSubmission_QueryData(t *testing.T) {
 					fields: []string{"customDate2", "customField2"},
 					// Although customText0 isn't really supported for job submission if attempted it error appropriately
 					// Test case for that below
-					wantErr: `unknown job submission field "customField2", only customDate0, customText0 and customFloat0 are valid`,
+					wantErr: `unknown job submission field "customField2", only customDate0, customText0, customTextBlock0, customFloat0 and customInt0 are valid`,
 				},
 				{
 					name:    "customText1 is not supported",
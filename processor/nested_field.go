@@ -0,0 +1,193 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var nestedFieldRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*(\.[a-zA-Z][a-zA-Z0-9]*)+$`)
+
+type nestedFieldError struct {
+	entity string
+	field  string
+}
+
+func (e nestedFieldError) Error() string {
+	return fmt.Sprintf("invalid %s nested field %q, expected a dotted path with an optional type like owner.email:string", e.entity, e.field)
+}
+
+type nestedField struct {
+	path         string
+	datasetField string
+	displayName  string
+	fieldType    geckoboard.FieldType
+	timeUnit     geckoboard.TimeUnit
+}
+
+type nestedFields []nestedField
+
+// fetchAndValidateNestedFields reads the dotted association paths from
+// the ENTITY_NESTEDFIELDS env, a path is a string unless a type is given
+func (nfs *nestedFields) fetchAndValidateNestedFields(entity string) error {
+	env := os.Getenv(fmt.Sprintf("%s_NESTEDFIELDS", strings.ReplaceAll(strings.ToUpper(entity), " ", "")))
+	if env == "" {
+		return nil
+	}
+
+	for _, f := range strings.Split(env, ",") {
+		field := strings.TrimSpace(f)
+
+		path, suffix := splitTypeSuffix(field)
+		if !nestedFieldRegexp.MatchString(path) {
+			return nestedFieldError{entity: entity, field: field}
+		}
+
+		fieldType, timeUnit := geckoboard.StringType, geckoboard.TimeUnit("")
+		if suffix != "" {
+			var ok bool
+			if fieldType, timeUnit, ok = parseTypeSuffix(suffix); !ok {
+				return nestedFieldError{entity: entity, field: field}
+			}
+		}
+
+		words := splitPath(path)
+		name := strings.Join(words, " ")
+
+		*nfs = append(*nfs, nestedField{
+			path:         path,
+			datasetField: strings.Join(words, "_"),
+			displayName:  strings.ToUpper(name[:1]) + name[1:],
+			fieldType:    fieldType,
+			timeUnit:     timeUnit,
+		})
+	}
+
+	return nil
+}
+
+func (nfs nestedFields) queryFields() []string {
+	fields := []string{}
+	for _, f := range nfs {
+		fields = append(fields, f.path)
+	}
+
+	return fields
+}
+
+func (nfs nestedFields) extractNestedFieldData(raw bullhorn.RawEntity, row geckoboard.DataRow) {
+	for _, f := range nfs {
+		val := raw.Lookup(f.path)
+
+		switch f.fieldType {
+		case geckoboard.NumberType, geckoboard.PercentType, geckoboard.DurationType:
+			row[f.datasetField] = nestedNumber(val)
+		case geckoboard.MoneyType:
+			row[f.datasetField] = nestedMoney(val)
+		case geckoboard.DatetimeType:
+			row[f.datasetField] = nestedDatetime(val)
+		case geckoboard.DateType:
+			row[f.datasetField] = nestedDate(val)
+		default:
+			row[f.datasetField] = nestedString(val)
+		}
+	}
+}
+
+func (nfs nestedFields) extractNestedFieldsForSchema(fields map[string]geckoboard.Field) {
+	for _, f := range nfs {
+		fields[f.datasetField] = schemaField(f.displayName, f.fieldType, f.timeUnit)
+	}
+}
+
+func nestedString(val interface{}) *string {
+	switch v := val.(type) {
+	case string:
+		return valueOrNil(truncateString(v, geckoboard.MaxStringLength))
+	case float64:
+		return valueOrNil(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		return valueOrNil(strings.ToUpper(strconv.FormatBool(v)))
+	case []interface{}:
+		vals := []string{}
+		for _, item := range v {
+			if s := nestedString(item); s != nil {
+				vals = append(vals, *s)
+			}
+		}
+
+		// Sort so the values are consistent regardless of the order returned
+		sort.Strings(vals)
+		return valueOrNil(truncateString(strings.Join(vals, " ; "), geckoboard.MaxStringLength))
+	}
+
+	return nil
+}
+
+func nestedNumber(val interface{}) *float64 {
+	switch v := val.(type) {
+	case float64:
+		return &v
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return &f
+		}
+	}
+
+	return nil
+}
+
+func nestedMoney(val interface{}) *int {
+	if v := nestedNumber(val); v != nil {
+		money := geckoboard.MoneyValue(*v)
+		return &money
+	}
+
+	return nil
+}
+
+func nestedDate(val interface{}) *string {
+	if v, ok := val.(float64); ok {
+		return dateValue(bullhorn.EpochMilli(v))
+	}
+
+	return nil
+}
+
+func nestedDatetime(val interface{}) *string {
+	if v, ok := val.(float64); ok {
+		return valueOrNil(bullhorn.EpochMilli(v).String())
+	}
+
+	return nil
+}
+
+// splitPath breaks up a camel cased dotted path into lower case words
+// so jobOrder.clientCorporation becomes job, order, client, corporation
+func splitPath(path string) []string {
+	words := []string{}
+	word := []rune{}
+
+	for _, r := range path {
+		if r == '.' || unicode.IsUpper(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+
+			word = []rune{}
+			if r == '.' {
+				continue
+			}
+		}
+
+		word = append(word, unicode.ToLower(r))
+	}
+
+	return append(words, string(word))
+}
@@ -0,0 +1,127 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"os"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNestedFields_FetchAndValidate(t *testing.T) {
+	defer os.Unsetenv("JOBORDER_NESTEDFIELDS")
+
+	t.Run("returns no fields when env is empty", func(t *testing.T) {
+		nfs := nestedFields{}
+		assert.NilError(t, nfs.fetchAndValidateNestedFields("job order"))
+		assert.Equal(t, len(nfs), 0)
+	})
+
+	t.Run("parses the paths and types", func(t *testing.T) {
+		os.Setenv("JOBORDER_NESTEDFIELDS", "owner.email, clientCorporation.dateAdded:datetime,clientCorporation.annualRevenue:number,clientCorporation.feeAmount:money,clientCorporation.dateFounded:date,clientCorporation.workWeekStart:duration:minutes")
+
+		nfs := nestedFields{}
+		assert.NilError(t, nfs.fetchAndValidateNestedFields("job order"))
+		assert.Equal(t, len(nfs), 6)
+
+		assert.Equal(t, nfs[0].path, "owner.email")
+		assert.Equal(t, nfs[0].datasetField, "owner_email")
+		assert.Equal(t, nfs[0].displayName, "Owner email")
+		assert.Equal(t, nfs[0].fieldType, geckoboard.StringType)
+
+		assert.Equal(t, nfs[1].path, "clientCorporation.dateAdded")
+		assert.Equal(t, nfs[1].datasetField, "client_corporation_date_added")
+		assert.Equal(t, nfs[1].fieldType, geckoboard.DatetimeType)
+
+		assert.Equal(t, nfs[2].datasetField, "client_corporation_annual_revenue")
+		assert.Equal(t, nfs[2].fieldType, geckoboard.NumberType)
+
+		assert.Equal(t, nfs[3].fieldType, geckoboard.MoneyType)
+		assert.Equal(t, nfs[4].fieldType, geckoboard.DateType)
+		assert.Equal(t, nfs[5].fieldType, geckoboard.DurationType)
+		assert.Equal(t, nfs[5].timeUnit, geckoboard.Minutes)
+
+		assert.DeepEqual(t, nfs.queryFields(), []string{
+			"owner.email", "clientCorporation.dateAdded", "clientCorporation.annualRevenue",
+			"clientCorporation.feeAmount", "clientCorporation.dateFounded", "clientCorporation.workWeekStart",
+		})
+	})
+
+	for _, field := range []string{"owner", "owner.email:currency", "owner.email:date:hours", "owner..email", "owner(email)"} {
+		t.Run("errors for "+field, func(t *testing.T) {
+			os.Setenv("JOBORDER_NESTEDFIELDS", field)
+
+			nfs := nestedFields{}
+			err := nfs.fetchAndValidateNestedFields("job order")
+			assert.Error(t, err, `invalid job order nested field "`+field+`", expected a dotted path with an optional type like owner.email:string`)
+		})
+	}
+}
+
+func TestNestedFields_ExtractData(t *testing.T) {
+	nfs := nestedFields{
+		{path: "owner.email", datasetField: "owner_email", fieldType: geckoboard.StringType},
+		{path: "owner.isEnabled", datasetField: "owner_enabled", fieldType: geckoboard.StringType},
+		{path: "categories.name", datasetField: "categories", fieldType: geckoboard.StringType},
+		{path: "address.notes", datasetField: "address_notes", fieldType: geckoboard.StringType},
+		{path: "clientCorporation.annualRevenue", datasetField: "revenue", fieldType: geckoboard.NumberType},
+		{path: "clientCorporation.dateAdded", datasetField: "client_added", fieldType: geckoboard.DatetimeType},
+		{path: "address.state", datasetField: "state", fieldType: geckoboard.StringType},
+		{path: "clientCorporation.feeAmount", datasetField: "fee_amount", fieldType: geckoboard.MoneyType},
+		{path: "clientCorporation.dateAdded", datasetField: "client_added_on", fieldType: geckoboard.DateType},
+	}
+
+	row := geckoboard.DataRow{}
+	nfs.extractNestedFieldData(bullhorn.RawEntity{
+		"owner": map[string]interface{}{"email": "owner@example.com", "isEnabled": true},
+		"categories": map[string]interface{}{
+			"data": []interface{}{
+				map[string]interface{}{"name": "B"},
+				map[string]interface{}{"name": "A"},
+			},
+		},
+		"address": map[string]interface{}{"notes": strings.Repeat("a", 300)},
+		"clientCorporation": map[string]interface{}{
+			"annualRevenue": float64(1200.5),
+			"feeAmount":     float64(1250.5),
+			"dateAdded":     float64(1659111234000),
+		},
+	}, row)
+
+	assert.DeepEqual(t, row, geckoboard.DataRow{
+		"owner_email":     stringPtr("owner@example.com"),
+		"owner_enabled":   stringPtr("TRUE"),
+		"categories":      stringPtr("A ; B"),
+		"address_notes":   stringPtr(strings.Repeat("a", 255)),
+		"revenue":         floatPtr(1200.5),
+		"client_added":    stringPtr("2022-07-29T16:13:54Z"),
+		"state":           (*string)(nil),
+		"fee_amount":      intPtr(125050),
+		"client_added_on": stringPtr("2022-07-29"),
+	})
+}
+
+func TestNestedFields_ExtractSchema(t *testing.T) {
+	nfs := nestedFields{
+		{datasetField: "owner_email", displayName: "Owner email", fieldType: geckoboard.StringType},
+		{datasetField: "revenue", displayName: "Revenue", fieldType: geckoboard.NumberType},
+		{datasetField: "fee_amount", displayName: "Fee amount", fieldType: geckoboard.MoneyType},
+		{datasetField: "work_week", displayName: "Work week", fieldType: geckoboard.DurationType, timeUnit: geckoboard.Minutes},
+	}
+
+	fields := map[string]geckoboard.Field{}
+	nfs.extractNestedFieldsForSchema(fields)
+
+	assert.DeepEqual(t, fields, map[string]geckoboard.Field{
+		"owner_email": {Name: "Owner email", Type: geckoboard.StringType, Optional: true},
+		"revenue":     {Name: "Revenue", Type: geckoboard.NumberType, Optional: true},
+		"fee_amount":  {Name: "Fee amount", Type: geckoboard.MoneyType, Optional: true, CurrencyCode: "USD"},
+		"work_week":   {Name: "Work week", Type: geckoboard.DurationType, Optional: true, TimeUnit: geckoboard.Minutes},
+	})
+}
+
+func intPtr(val int) *int {
+	return &val
+}
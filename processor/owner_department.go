@@ -0,0 +1,105 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const ownerDepartmentEnv = "OWNER_DEPARTMENT_ENRICHMENT"
+
+// ownerDepartments maps a corporate user id to the name of their
+// primary department, it is nil when the enrichment isn't enabled
+type ownerDepartments map[int]string
+
+func ownerDepartmentEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(ownerDepartmentEnv))
+	return enabled
+}
+
+func fetchOwnerDepartments(ctx context.Context, client *bullhorn.Client, recordsPerPage int) (ownerDepartments, error) {
+	if !ownerDepartmentEnabled() {
+		return nil, nil
+	}
+
+	users, err := queryCorporateUsers(ctx, client, recordsPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	depts := ownerDepartments{}
+	for _, u := range users {
+		depts[u.ID] = u.PrimaryDepartment.Name
+	}
+
+	return depts, nil
+}
+
+// ownerDepartmentCache is shared by the processors enriched with the owner
+// departments so the corporate users are only queried once per run
+type ownerDepartmentCache struct {
+	client         *bullhorn.Client
+	recordsPerPage int
+
+	mu          sync.Mutex
+	fetched     bool
+	departments ownerDepartments
+	err         error
+}
+
+func newOwnerDepartmentCache(client *bullhorn.Client, recordsPerPage int) *ownerDepartmentCache {
+	return &ownerDepartmentCache{client: client, recordsPerPage: recordsPerPage}
+}
+
+// get returns the owner departments fetched during the run, fetching
+// them the first time. A nil cache has no departments
+func (c *ownerDepartmentCache) get(ctx context.Context) (ownerDepartments, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetched {
+		c.departments, c.err = fetchOwnerDepartments(ctx, c.client, c.recordsPerPage)
+		c.fetched = true
+	}
+
+	return c.departments, c.err
+}
+
+// reset has the departments fetched again by the next run
+func (c *ownerDepartmentCache) reset() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fetched, c.departments, c.err = false, nil, nil
+}
+
+func (o ownerDepartments) extractOwnerDepartmentData(owner bullhorn.Person, row geckoboard.DataRow) {
+	if o == nil {
+		return
+	}
+
+	row["owner_department"] = valueOrNotSet(o[owner.ID])
+}
+
+func extractOwnerDepartmentForSchema(fields map[string]geckoboard.Field) {
+	if !ownerDepartmentEnabled() {
+		return
+	}
+
+	fields["owner_department"] = geckoboard.Field{
+		Name:     "Owner department",
+		Type:     geckoboard.StringType,
+		Optional: true,
+	}
+}
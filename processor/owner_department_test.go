@@ -0,0 +1,174 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"bullhorn-to-dataset/sink"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestOwnerDepartments(t *testing.T) {
+	unsetEnv := func() {
+		os.Unsetenv(ownerDepartmentEnv)
+	}
+
+	t.Run("doesn't query users when not enabled", func(t *testing.T) {
+		bc := bullhorn.New("")
+
+		got, err := fetchOwnerDepartments(context.Background(), bc, 200)
+		assert.NilError(t, err)
+		assert.Assert(t, got == nil)
+
+		row := geckoboard.DataRow{}
+		got.extractOwnerDepartmentData(bullhorn.Person{ID: 1}, row)
+		assert.DeepEqual(t, row, geckoboard.DataRow{})
+
+		fields := map[string]geckoboard.Field{}
+		extractOwnerDepartmentForSchema(fields)
+		assert.DeepEqual(t, fields, map[string]geckoboard.Field{})
+	})
+
+	t.Run("adds the owner department when enabled", func(t *testing.T) {
+		defer unsetEnv()
+		os.Setenv(ownerDepartmentEnv, "true")
+
+		bc := bullhorn.New("")
+		bc.CorporateUserService = newCorporateUserService(t, testCorporateUsers)
+
+		got, err := fetchOwnerDepartments(context.Background(), bc, 200)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, ownerDepartments{1: "Sales", 2: ""})
+
+		row := geckoboard.DataRow{}
+		got.extractOwnerDepartmentData(bullhorn.Person{ID: 1}, row)
+		assert.DeepEqual(t, row, geckoboard.DataRow{"owner_department": "Sales"})
+
+		got.extractOwnerDepartmentData(bullhorn.Person{}, row)
+		assert.DeepEqual(t, row, geckoboard.DataRow{"owner_department": "(not set)"})
+
+		fields := map[string]geckoboard.Field{}
+		extractOwnerDepartmentForSchema(fields)
+		assert.DeepEqual(t, fields, map[string]geckoboard.Field{
+			"owner_department": {
+				Name:     "Owner department",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+		})
+	})
+
+	t.Run("returns error when user query fails", func(t *testing.T) {
+		defer unsetEnv()
+		os.Setenv(ownerDepartmentEnv, "true")
+
+		bc := bullhorn.New("")
+		bc.CorporateUserService = mockCorporateUserService{
+			searchFn: func(bullhorn.SearchQuery) (*bullhorn.CorporateUsers, error) {
+				return nil, errors.New("query users failed")
+			},
+		}
+
+		_, err := fetchOwnerDepartments(context.Background(), bc, 200)
+		assert.Error(t, err, "query users failed")
+	})
+
+	t.Run("enriches job order rows with the owner department", func(t *testing.T) {
+		defer unsetEnv()
+		os.Setenv(ownerDepartmentEnv, "true")
+
+		bc := bullhorn.New("")
+		bc.JobOrderService = newJobOrderService(t, []bullhorn.JobOrder{
+			{ID: 1, Owner: bullhorn.Person{ID: 1, FirstName: "User", LastName: "A"}},
+		})
+		bc.CorporateUserService = newCorporateUserService(t, testCorporateUsers)
+
+		proc := jobOrderProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			ordersPerPage:     200,
+			departments:       newOwnerDepartmentCache(bc, 200),
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data[0]["owner"], stringPtr("User A"))
+		assert.Equal(t, data[0]["owner_department"], "Sales")
+
+		_, ok := proc.Schema().Fields["owner_department"]
+		assert.Assert(t, ok)
+	})
+
+	t.Run("enriches placement rows with the owner department", func(t *testing.T) {
+		defer unsetEnv()
+		os.Setenv(ownerDepartmentEnv, "true")
+
+		bc := bullhorn.New("")
+		bc.PlacementService = newPlacementService(t, []bullhorn.Placement{
+			{ID: 1, Owner: bullhorn.Person{ID: 1, FirstName: "User", LastName: "A"}},
+		})
+		bc.CorporateUserService = newCorporateUserService(t, testCorporateUsers)
+
+		proc := placementProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+			departments:       newOwnerDepartmentCache(bc, 200),
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data[0]["owner"], stringPtr("User A"))
+		assert.Equal(t, data[0]["owner_department"], "Sales")
+
+		_, ok := proc.Schema().Fields["owner_department"]
+		assert.Assert(t, ok)
+	})
+
+	t.Run("queries the users once per run", func(t *testing.T) {
+		defer unsetEnv()
+		os.Setenv(ownerDepartmentEnv, "true")
+
+		userRequests := 0
+
+		bc := bullhorn.New("")
+		bc.JobOrderService = newJobOrderService(t, []bullhorn.JobOrder{
+			{ID: 1, Owner: bullhorn.Person{ID: 1, FirstName: "User", LastName: "A"}},
+		})
+		bc.PlacementService = newPlacementService(t, []bullhorn.Placement{
+			{ID: 1, Owner: bullhorn.Person{ID: 1, FirstName: "User", LastName: "A"}},
+		})
+		bc.CorporateUserService = mockCorporateUserService{
+			searchFn: func(bullhorn.SearchQuery) (*bullhorn.CorporateUsers, error) {
+				userRequests++
+				return &bullhorn.CorporateUsers{Items: testCorporateUsers}, nil
+			},
+		}
+
+		departments := newOwnerDepartmentCache(bc, 200)
+		file := &mockSink{name: "csv"}
+		proc := Processor{
+			processors: []datasetProcessor{
+				&jobOrderProcessor{client: bc, maxDatasetRecords: 50, ordersPerPage: 200, departments: departments},
+				&placementProcessor{client: bc, maxDatasetRecords: 50, recordsPerPage: 200, departments: departments},
+			},
+			sinks:       []sink.Sink{file},
+			printer:     &mockLogPrinter{},
+			departments: departments,
+		}
+
+		proc.ProcessAll(context.Background())
+		assert.Equal(t, userRequests, 1)
+		assert.Equal(t, len(file.written), 2)
+		for _, data := range file.written {
+			assert.Equal(t, data[0]["owner_department"], "Sales")
+		}
+
+		proc.ProcessAll(context.Background())
+		assert.Equal(t, userRequests, 2)
+	})
+}
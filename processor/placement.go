@@ -11,9 +11,11 @@ import (
 
 var (
 	placementCustomFieldRules = map[string]int{
-		"Date":  13,
-		"Text":  60,
-		"Float": 23,
+		"Date":      13,
+		"Text":      60,
+		"Float":     23,
+		"Int":       23,
+		"TextBlock": 10,
 	}
 )
 
@@ -23,6 +25,8 @@ type placementProcessor struct {
 	maxDatasetRecords int
 	recordsPerPage    int
 	customFields      customFields
+	nestedFields      nestedFields
+	departments       *ownerDepartmentCache
 }
 
 func (placementProcessor) String() string {
@@ -34,12 +38,19 @@ func (p *placementProcessor) QueryData(ctx context.Context) (geckoboard.Data, er
 		return nil, err
 	}
 
+	if err := p.nestedFields.fetchAndValidateNestedFields(p.String()); err != nil {
+		return nil, err
+	}
+
 	placements, err := p.queryPlacements(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Println("Queried", len(placements), "placements")
+	departments, err := p.departments.get(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	maxIndex := int(math.Min(float64(len(placements)), float64(p.maxDatasetRecords)))
 	latestPlacements := placements[0:maxIndex]
@@ -60,9 +71,22 @@ func (p *placementProcessor) QueryData(ctx context.Context) (geckoboard.Data, er
 			"referral_fee":      r.ReferralFee,
 			"referral_fee_type": r.ReferralFeeType,
 			"status":            r.Status,
+
+			"salary":             moneyOrNil(r.Salary),
+			"pay_rate":           moneyOrNil(r.PayRate),
+			"client_bill_rate":   moneyOrNil(r.ClientBillRate),
+			"spread_per_hour":    spreadPerHour(r.PayRate, r.ClientBillRate),
+			"gross_margin":       grossMargin(r.PayRate, r.ClientBillRate),
+			"hours_per_day":      r.HoursPerDay,
+			"duration_weeks":     r.DurationWeeks,
+			"candidate":          r.Candidate.FullName(),
+			"owner":              r.Owner.FullName(),
+			"client_corporation": valueOrNotSet(r.JobOrder.ClientCorporation.Name),
 		}
 
 		p.customFields.extractCustomFieldData(r, entry)
+		p.nestedFields.extractNestedFieldData(r.Raw, entry)
+		departments.extractOwnerDepartmentData(r.Owner, entry)
 		data = append(data, entry)
 	}
 
@@ -132,9 +156,65 @@ func (p *placementProcessor) Schema() *geckoboard.Dataset {
 			Type:     geckoboard.StringType,
 			Optional: true,
 		},
+		"salary": {
+			Name:         "Salary",
+			Type:         geckoboard.MoneyType,
+			Optional:     true,
+			CurrencyCode: currencyCode(),
+		},
+		"pay_rate": {
+			Name:         "Pay rate",
+			Type:         geckoboard.MoneyType,
+			Optional:     true,
+			CurrencyCode: currencyCode(),
+		},
+		"client_bill_rate": {
+			Name:         "Client bill rate",
+			Type:         geckoboard.MoneyType,
+			Optional:     true,
+			CurrencyCode: currencyCode(),
+		},
+		"spread_per_hour": {
+			Name:         "Spread per hour",
+			Type:         geckoboard.MoneyType,
+			Optional:     true,
+			CurrencyCode: currencyCode(),
+		},
+		"gross_margin": {
+			Name:     "Gross margin",
+			Type:     geckoboard.PercentType,
+			Optional: true,
+		},
+		"hours_per_day": {
+			Name:     "Hours per day",
+			Type:     geckoboard.NumberType,
+			Optional: true,
+		},
+		"duration_weeks": {
+			Name:     "Duration weeks",
+			Type:     geckoboard.NumberType,
+			Optional: true,
+		},
+		"candidate": {
+			Name:     "Candidate",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+		"owner": {
+			Name:     "Owner",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
+		"client_corporation": {
+			Name:     "Client corporation",
+			Type:     geckoboard.StringType,
+			Optional: true,
+		},
 	}
 
 	p.customFields.extractCustomFieldsForSchema(datasetFields)
+	p.nestedFields.extractNestedFieldsForSchema(datasetFields)
+	extractOwnerDepartmentForSchema(datasetFields)
 
 	return &geckoboard.Dataset{
 		Name:     "bullhorn-placements",
@@ -143,24 +223,64 @@ func (p *placementProcessor) Schema() *geckoboard.Dataset {
 	}
 }
 
+// grossMargin is the share of the client bill rate kept after paying
+// the candidate, it's nil when there is no bill rate to work it out from
+// moneyOrNil returns nil for an amount which isn't set, as Bullhorn
+// returns 0 for those and they'd skew the averages on a dashboard
+func moneyOrNil(amount float64) *int {
+	if amount == 0 {
+		return nil
+	}
+
+	v := geckoboard.MoneyValue(amount)
+	return &v
+}
+
+// spreadPerHour is only worked out when both rates are set, as
+// salaried placements don't have either
+func spreadPerHour(payRate, billRate float64) *int {
+	if payRate == 0 || billRate == 0 {
+		return nil
+	}
+
+	v := geckoboard.MoneyValue(billRate - payRate)
+	return &v
+}
+
+// grossMargin is only worked out when both rates are set,
+// otherwise a missing pay rate would be a 100% margin
+func grossMargin(payRate, billRate float64) *float64 {
+	if payRate == 0 || billRate == 0 {
+		return nil
+	}
+
+	margin := (billRate - payRate) / billRate
+	return &margin
+}
+
 func (p *placementProcessor) queryPlacements(ctx context.Context) ([]bullhorn.Placement, error) {
 	var placements []bullhorn.Placement
 
 	queryFields := []string{
 		"id", "dateAdded", "dateBegin", "dateEnd", "dateLastModified",
 		"employeeType", "employmentType", "fee", "jobOrder", "onboardingStatus",
-		"referralFee", "referralFeeType", "status",
+		"referralFee", "referralFeeType", "status", "salary", "payRate",
+		"clientBillRate", "hoursPerDay", "durationWeeks", "candidate", "owner",
+		"jobOrder.clientCorporation.id", "jobOrder.clientCorporation.name",
 	}
 
 	for _, f := range p.customFields {
 		queryFields = append(queryFields, f.sanitized)
 	}
 
+	queryFields = append(queryFields, p.nestedFields.queryFields()...)
+
 	query := bullhorn.SearchQuery{
-		Fields: queryFields,
-		Where:  "id>0",
-		Start:  0,
-		Count:  p.recordsPerPage,
+		Fields:     queryFields,
+		Where:      "id>0",
+		Start:      0,
+		Count:      p.recordsPerPage,
+		IncludeRaw: len(p.nestedFields) > 0,
 	}
 
 	for {
@@ -0,0 +1,150 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"math"
+	"strconv"
+)
+
+type placementCommissionProcessor struct {
+	client *bullhorn.Client
+
+	maxDatasetRecords int
+	recordsPerPage    int
+}
+
+func (placementCommissionProcessor) String() string {
+	return "placement commission"
+}
+
+// QueryData returns a row per placement and commissioned user, with the share
+// of the placement fee credited to that user so split deals are counted once
+func (p *placementCommissionProcessor) QueryData(ctx context.Context) (geckoboard.Data, error) {
+	commissions, err := p.queryPlacementCommissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIndex := int(math.Min(float64(len(commissions)), float64(p.maxDatasetRecords)))
+	latestCommissions := commissions[0:maxIndex]
+
+	data := geckoboard.Data{}
+	for _, c := range latestCommissions {
+		entry := geckoboard.DataRow{
+			"id":                    strconv.Itoa(c.ID),
+			"placement_id":          strconv.Itoa(c.Placement.ID),
+			"placement_status":      valueOrNotSet(c.Placement.Status),
+			"user":                  c.User.FullName(),
+			"role":                  valueOrNotSet(c.Role),
+			"commission_percentage": c.CommissionPercentage,
+			"credited_fee":          c.Placement.Fee * c.CommissionPercentage,
+			"credited_amount":       creditedAmount(c),
+			"date_added":            valueOrNil(c.DateAdded.String()),
+		}
+
+		data = append(data, entry)
+	}
+
+	return data, nil
+}
+
+// creditedAmount is the user's share of the placement fee amount, which is
+// the flat fee when there is one or else the fee percentage of the salary.
+// It's nil when the placement has neither so it isn't counted as zero
+func creditedAmount(c bullhorn.PlacementCommission) *int {
+	feeAmount := c.Placement.FlatFee
+	if feeAmount == 0 {
+		feeAmount = c.Placement.Salary * c.Placement.Fee
+	}
+
+	if feeAmount == 0 {
+		return nil
+	}
+
+	amount := geckoboard.MoneyValue(feeAmount * c.CommissionPercentage)
+	return &amount
+}
+
+func (p *placementCommissionProcessor) Schema() *geckoboard.Dataset {
+	return &geckoboard.Dataset{
+		Name: "bullhorn-placement-commissions",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"placement_id": {
+				Name:     "Placement ID",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"placement_status": {
+				Name:     "Placement status",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"user": {
+				Name:     "User",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"role": {
+				Name:     "Role",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"commission_percentage": {
+				Name:     "Commission %",
+				Type:     geckoboard.PercentType,
+				Optional: true,
+			},
+			"credited_fee": {
+				Name:     "Credited fee %",
+				Type:     geckoboard.PercentType,
+				Optional: true,
+			},
+			"credited_amount": {
+				Name:         "Credited amount",
+				Type:         geckoboard.MoneyType,
+				Optional:     true,
+				CurrencyCode: currencyCode(),
+			},
+			"date_added": {
+				Name: "Date added", Type: geckoboard.DatetimeType,
+				Optional: true,
+			},
+		},
+		UniqueBy: []string{"id"},
+	}
+}
+
+func (p *placementCommissionProcessor) queryPlacementCommissions(ctx context.Context) ([]bullhorn.PlacementCommission, error) {
+	var commissions []bullhorn.PlacementCommission
+
+	query := bullhorn.SearchQuery{
+		Fields: []string{
+			"id", "dateAdded", "commissionPercentage", "placement(id,fee,flatFee,salary,status)",
+			"role", "status", "user",
+		},
+		Where: "id>0",
+		Start: 0,
+		Count: p.recordsPerPage,
+	}
+
+	for {
+		cs, err := p.client.PlacementCommissionService.Search(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		commissions = append(commissions, cs.Items...)
+		if len(cs.Items) < query.Count || len(commissions) >= p.maxDatasetRecords {
+			return commissions, nil
+		}
+
+		query.Start = query.Count + query.Start
+	}
+}
@@ -0,0 +1,280 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var (
+	wantPlacementCommissionFields = []string{
+		"id", "dateAdded", "commissionPercentage", "placement(id,fee,flatFee,salary,status)",
+		"role", "status", "user",
+	}
+
+	wantPlacementCommissionData = geckoboard.Data{
+		{
+			"id":                    "3",
+			"placement_id":          "10",
+			"placement_status":      "Approved",
+			"user":                  stringPtr("User A"),
+			"role":                  "Recruiter",
+			"commission_percentage": float64(0.5),
+			"credited_fee":          float64(0.125),
+			"credited_amount":       intPtr(1000000),
+			"date_added":            stringPtr("2022-07-30T14:10:21Z"),
+		},
+		{
+			"id":                    "2",
+			"placement_id":          "10",
+			"placement_status":      "Approved",
+			"user":                  stringPtr("User B"),
+			"role":                  "Account Manager",
+			"commission_percentage": float64(0.5),
+			"credited_fee":          float64(0.125),
+			"credited_amount":       intPtr(1000000),
+			"date_added":            stringPtr("2022-07-30T11:23:41Z"),
+		},
+		{
+			"id":                    "1",
+			"placement_id":          "8",
+			"placement_status":      "(not set)",
+			"user":                  (*string)(nil),
+			"role":                  "(not set)",
+			"commission_percentage": float64(1),
+			"credited_fee":          float64(0.5),
+			"credited_amount":       intPtr(500000),
+			"date_added":            stringPtr("2022-07-30T08:37:01Z"),
+		},
+	}
+
+	testPlacementCommissions = []bullhorn.PlacementCommission{
+		{
+			ID:                   3,
+			DateAdded:            1659190221000,
+			CommissionPercentage: 0.5,
+			Placement:            bullhorn.Placement{ID: 10, Fee: 0.25, Salary: 80000, Status: "Approved"},
+			Role:                 "Recruiter",
+			User:                 bullhorn.Person{FirstName: "User", LastName: "A"},
+		},
+		{
+			ID:                   2,
+			DateAdded:            1659180221000,
+			CommissionPercentage: 0.5,
+			Placement:            bullhorn.Placement{ID: 10, Fee: 0.25, Salary: 80000, Status: "Approved"},
+			Role:                 "Account Manager",
+			User:                 bullhorn.Person{FirstName: "User", LastName: "B"},
+		},
+		{
+			ID:                   1,
+			DateAdded:            1659170221000,
+			CommissionPercentage: 1,
+			Placement:            bullhorn.Placement{ID: 8, Fee: 0.5, FlatFee: 5000},
+		},
+	}
+)
+
+func TestPlacementCommission_String(t *testing.T) {
+	p := placementCommissionProcessor{}
+	assert.Equal(t, p.String(), "placement commission")
+}
+
+func TestPlacementCommission_Schema(t *testing.T) {
+	got := (&placementCommissionProcessor{}).Schema()
+	want := &geckoboard.Dataset{
+		Name: "bullhorn-placement-commissions",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"placement_id": {
+				Name:     "Placement ID",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"placement_status": {
+				Name:     "Placement status",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"user": {
+				Name:     "User",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"role": {
+				Name:     "Role",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"commission_percentage": {
+				Name:     "Commission %",
+				Type:     geckoboard.PercentType,
+				Optional: true,
+			},
+			"credited_fee": {
+				Name:     "Credited fee %",
+				Type:     geckoboard.PercentType,
+				Optional: true,
+			},
+			"credited_amount": {
+				Name:         "Credited amount",
+				Type:         geckoboard.MoneyType,
+				Optional:     true,
+				CurrencyCode: "USD",
+			},
+			"date_added": {
+				Name: "Date added", Type: geckoboard.DatetimeType,
+				Optional: true,
+			},
+		},
+		UniqueBy: []string{"id"},
+	}
+
+	assert.DeepEqual(t, got, want)
+}
+
+func TestPlacementCommission_CreditedAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		placement  bullhorn.Placement
+		percentage float64
+		want       *int
+	}{
+		{name: "salary fee", placement: bullhorn.Placement{Salary: 80000, Fee: 0.25}, percentage: 0.5, want: intPtr(1000000)},
+		{name: "flat fee", placement: bullhorn.Placement{Salary: 80000, Fee: 0.25, FlatFee: 5000}, percentage: 0.5, want: intPtr(250000)},
+		{name: "no salary or flat fee", placement: bullhorn.Placement{Fee: 0.25}, percentage: 0.5, want: nil},
+		{name: "no commission share", placement: bullhorn.Placement{FlatFee: 5000}, want: intPtr(0)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := creditedAmount(bullhorn.PlacementCommission{Placement: tc.placement, CommissionPercentage: tc.percentage})
+			assert.DeepEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestPlacementCommission_QueryData(t *testing.T) {
+	t.Run("returns a row per placement commission", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.PlacementCommissionService = newPlacementCommissionService(t, testPlacementCommissions)
+
+		proc := placementCommissionProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantPlacementCommissionData)
+	})
+
+	t.Run("paginates until records are less than the count", func(t *testing.T) {
+		bullhornRequests := 0
+
+		bc := bullhorn.New("")
+		bc.PlacementCommissionService = mockPlacementCommissionService{
+			searchFn: func(got bullhorn.SearchQuery) (*bullhorn.PlacementCommissions, error) {
+				bullhornRequests += 1
+				want := bullhorn.SearchQuery{
+					Fields: wantPlacementCommissionFields,
+					Where:  "id>0",
+					Count:  2,
+				}
+
+				switch bullhornRequests {
+				case 1:
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.PlacementCommissions{
+						Items: testPlacementCommissions[:2],
+					}, nil
+				case 2:
+					want.Start = 2
+					assert.DeepEqual(t, got, want)
+
+					return &bullhorn.PlacementCommissions{
+						Items: testPlacementCommissions[2:],
+					}, nil
+				}
+
+				return nil, errors.New("shouldn't have got here")
+			},
+		}
+
+		proc := placementCommissionProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    2,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantPlacementCommissionData)
+	})
+
+	t.Run("returns only the max dataset records", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.PlacementCommissionService = newPlacementCommissionService(t, testPlacementCommissions)
+
+		proc := placementCommissionProcessor{
+			client:            bc,
+			maxDatasetRecords: 2,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantPlacementCommissionData[:2])
+	})
+
+	t.Run("returns error when commission query fails", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.PlacementCommissionService = mockPlacementCommissionService{
+			searchFn: func(bullhorn.SearchQuery) (*bullhorn.PlacementCommissions, error) {
+				return nil, errors.New("query commissions failed")
+			},
+		}
+
+		proc := placementCommissionProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		_, err := proc.QueryData(context.Background())
+		assert.Error(t, err, "query commissions failed")
+	})
+}
+
+type mockPlacementCommissionService struct {
+	searchFn func(bullhorn.SearchQuery) (*bullhorn.PlacementCommissions, error)
+}
+
+func newPlacementCommissionService(t *testing.T, recs []bullhorn.PlacementCommission) mockPlacementCommissionService {
+	return mockPlacementCommissionService{
+		searchFn: func(got bullhorn.SearchQuery) (*bullhorn.PlacementCommissions, error) {
+			want := bullhorn.SearchQuery{
+				Fields: wantPlacementCommissionFields,
+				Where:  "id>0",
+				Count:  200,
+			}
+
+			assert.DeepEqual(t, got, want)
+			return &bullhorn.PlacementCommissions{
+				Items: recs,
+			}, nil
+		},
+	}
+}
+
+func (m mockPlacementCommissionService) Search(_ context.Context, query bullhorn.SearchQuery) (*bullhorn.PlacementCommissions, error) {
+	return m.searchFn(query)
+}
@@ -15,54 +15,86 @@ import (
 var (
 	wantPlacementFields = []string{
 		"id", "dateAdded", "dateBegin", "dateEnd", "dateLastModified", "employeeType", "employmentType",
-		"fee", "jobOrder", "onboardingStatus", "referralFee", "referralFeeType", "status",
+		"fee", "jobOrder", "onboardingStatus", "referralFee", "referralFeeType", "status", "salary", "payRate",
+		"clientBillRate", "hoursPerDay", "durationWeeks", "candidate", "owner",
+		"jobOrder.clientCorporation.id", "jobOrder.clientCorporation.name",
 	}
 
 	wantPlacementData = geckoboard.Data{
 		{
-			"date_added":        stringPtr("2022-07-30T14:10:21Z"),
-			"date_begin":        stringPtr("2022-07-31T17:57:01Z"),
-			"date_ended":        stringPtr("2022-08-08T20:23:41Z"),
-			"employee_type":     "1",
-			"employment_type":   "Contract",
-			"fee":               float64(123),
-			"id":                "1",
-			"job_order":         "Job Title ABC (99)",
-			"onboarding_status": "Completed",
-			"referral_fee":      float64(25),
-			"referral_fee_type": "percentage",
-			"status":            "Active",
-			"updated_at":        stringPtr("2022-07-30T15:00:21Z"),
+			"date_added":         stringPtr("2022-07-30T14:10:21Z"),
+			"date_begin":         stringPtr("2022-07-31T17:57:01Z"),
+			"date_ended":         stringPtr("2022-08-08T20:23:41Z"),
+			"employee_type":      "1",
+			"employment_type":    "Contract",
+			"fee":                float64(123),
+			"id":                 "1",
+			"job_order":          "Job Title ABC (99)",
+			"onboarding_status":  "Completed",
+			"referral_fee":       float64(25),
+			"referral_fee_type":  "percentage",
+			"status":             "Active",
+			"updated_at":         stringPtr("2022-07-30T15:00:21Z"),
+			"salary":             (*int)(nil),
+			"pay_rate":           intPtr(4000),
+			"client_bill_rate":   intPtr(5050),
+			"spread_per_hour":    intPtr(1050),
+			"gross_margin":       floatPtr(10.5 / 50.5),
+			"hours_per_day":      float64(8),
+			"duration_weeks":     float64(12),
+			"candidate":          stringPtr("Candidate A"),
+			"owner":              stringPtr("Owner B"),
+			"client_corporation": "GeckoOrg",
 		},
 		{
-			"date_added":        stringPtr("2022-07-30T11:23:41Z"),
-			"date_begin":        stringPtr("2022-07-31T15:10:21Z"),
-			"date_ended":        stringPtr("2022-08-08T17:37:01Z"),
-			"employee_type":     "1",
-			"employment_type":   "Contract",
-			"fee":               float64(2123),
-			"id":                "2",
-			"job_order":         "Job Title CEF (299)",
-			"onboarding_status": "Completed",
-			"referral_fee":      float64(225),
-			"referral_fee_type": "percentage",
-			"status":            "Active",
-			"updated_at":        stringPtr("2022-07-30T12:13:41Z"),
+			"date_added":         stringPtr("2022-07-30T11:23:41Z"),
+			"date_begin":         stringPtr("2022-07-31T15:10:21Z"),
+			"date_ended":         stringPtr("2022-08-08T17:37:01Z"),
+			"employee_type":      "1",
+			"employment_type":    "Contract",
+			"fee":                float64(2123),
+			"id":                 "2",
+			"job_order":          "Job Title CEF (299)",
+			"onboarding_status":  "Completed",
+			"referral_fee":       float64(225),
+			"referral_fee_type":  "percentage",
+			"status":             "Active",
+			"updated_at":         stringPtr("2022-07-30T12:13:41Z"),
+			"salary":             intPtr(5500050),
+			"pay_rate":           (*int)(nil),
+			"client_bill_rate":   (*int)(nil),
+			"spread_per_hour":    (*int)(nil),
+			"gross_margin":       (*float64)(nil),
+			"hours_per_day":      float64(0),
+			"duration_weeks":     float64(0),
+			"candidate":          (*string)(nil),
+			"owner":              (*string)(nil),
+			"client_corporation": "(not set)",
 		},
 		{
-			"date_added":        stringPtr("2022-07-30T08:37:01Z"),
-			"date_begin":        stringPtr("2022-07-31T12:23:41Z"),
-			"date_ended":        stringPtr("2022-08-08T14:50:21Z"),
-			"employee_type":     "Contractor",
-			"employment_type":   "Contract",
-			"fee":               float64(3123),
-			"id":                "3",
-			"job_order":         "Job Title GHI (399)",
-			"onboarding_status": "Canceled",
-			"referral_fee":      float64(0),
-			"referral_fee_type": "",
-			"status":            "Terminated",
-			"updated_at":        stringPtr("2022-07-30T09:27:01Z"),
+			"date_added":         stringPtr("2022-07-30T08:37:01Z"),
+			"date_begin":         stringPtr("2022-07-31T12:23:41Z"),
+			"date_ended":         stringPtr("2022-08-08T14:50:21Z"),
+			"employee_type":      "Contractor",
+			"employment_type":    "Contract",
+			"fee":                float64(3123),
+			"id":                 "3",
+			"job_order":          "Job Title GHI (399)",
+			"onboarding_status":  "Canceled",
+			"referral_fee":       float64(0),
+			"referral_fee_type":  "",
+			"status":             "Terminated",
+			"updated_at":         stringPtr("2022-07-30T09:27:01Z"),
+			"salary":             (*int)(nil),
+			"pay_rate":           (*int)(nil),
+			"client_bill_rate":   (*int)(nil),
+			"spread_per_hour":    (*int)(nil),
+			"gross_margin":       (*float64)(nil),
+			"hours_per_day":      float64(0),
+			"duration_weeks":     float64(0),
+			"candidate":          (*string)(nil),
+			"owner":              (*string)(nil),
+			"client_corporation": "(not set)",
 		},
 	}
 
@@ -88,10 +120,19 @@ var (
 			EmployeeType:   "1",
 			EmploymentType: "Contract",
 			Fee:            123,
-			JobOrder: bullhorn.NestedEntity{
-				ID:    99,
-				Title: "Job Title ABC",
+			JobOrder: bullhorn.PlacementJobOrder{
+				NestedEntity: bullhorn.NestedEntity{
+					ID:    99,
+					Title: "Job Title ABC",
+				},
+				ClientCorporation: bullhorn.NestedEntity{ID: 5, Name: "GeckoOrg"},
 			},
+			PayRate:          40,
+			ClientBillRate:   50.5,
+			HoursPerDay:      8,
+			DurationWeeks:    12,
+			Candidate:        bullhorn.Person{FirstName: "Candidate", LastName: "A"},
+			Owner:            bullhorn.Person{FirstName: "Owner", LastName: "B"},
 			OnboardingStatus: "Completed",
 			ReferralFee:      25,
 			ReferralFeeType:  "percentage",
@@ -118,10 +159,13 @@ var (
 			EmployeeType:   "1",
 			EmploymentType: "Contract",
 			Fee:            2123,
-			JobOrder: bullhorn.NestedEntity{
-				ID:    299,
-				Title: "Job Title CEF",
+			JobOrder: bullhorn.PlacementJobOrder{
+				NestedEntity: bullhorn.NestedEntity{
+					ID:    299,
+					Title: "Job Title CEF",
+				},
 			},
+			Salary:           55000.5,
 			OnboardingStatus: "Completed",
 			ReferralFee:      225,
 			ReferralFeeType:  "percentage",
@@ -148,9 +192,11 @@ var (
 			EmployeeType:   "Contractor",
 			EmploymentType: "Contract",
 			Fee:            3123,
-			JobOrder: bullhorn.NestedEntity{
-				ID:    399,
-				Title: "Job Title GHI",
+			JobOrder: bullhorn.PlacementJobOrder{
+				NestedEntity: bullhorn.NestedEntity{
+					ID:    399,
+					Title: "Job Title GHI",
+				},
 			},
 			OnboardingStatus: "Canceled",
 			Status:           "Terminated",
@@ -230,6 +276,60 @@ func TestPlacement_Schema(t *testing.T) {
 					Type:     geckoboard.StringType,
 					Optional: true,
 				},
+				"salary": {
+					Name:         "Salary",
+					Type:         geckoboard.MoneyType,
+					Optional:     true,
+					CurrencyCode: "USD",
+				},
+				"pay_rate": {
+					Name:         "Pay rate",
+					Type:         geckoboard.MoneyType,
+					Optional:     true,
+					CurrencyCode: "USD",
+				},
+				"client_bill_rate": {
+					Name:         "Client bill rate",
+					Type:         geckoboard.MoneyType,
+					Optional:     true,
+					CurrencyCode: "USD",
+				},
+				"spread_per_hour": {
+					Name:         "Spread per hour",
+					Type:         geckoboard.MoneyType,
+					Optional:     true,
+					CurrencyCode: "USD",
+				},
+				"gross_margin": {
+					Name:     "Gross margin",
+					Type:     geckoboard.PercentType,
+					Optional: true,
+				},
+				"hours_per_day": {
+					Name:     "Hours per day",
+					Type:     geckoboard.NumberType,
+					Optional: true,
+				},
+				"duration_weeks": {
+					Name:     "Duration weeks",
+					Type:     geckoboard.NumberType,
+					Optional: true,
+				},
+				"candidate": {
+					Name:     "Candidate",
+					Type:     geckoboard.StringType,
+					Optional: true,
+				},
+				"owner": {
+					Name:     "Owner",
+					Type:     geckoboard.StringType,
+					Optional: true,
+				},
+				"client_corporation": {
+					Name:     "Client corporation",
+					Type:     geckoboard.StringType,
+					Optional: true,
+				},
 			},
 			UniqueBy: []string{"id"},
 		}
@@ -322,6 +422,60 @@ func TestPlacement_Schema(t *testing.T) {
 					Type:     geckoboard.StringType,
 					Optional: true,
 				},
+				"salary": {
+					Name:         "Salary",
+					Type:         geckoboard.MoneyType,
+					Optional:     true,
+					CurrencyCode: "USD",
+				},
+				"pay_rate": {
+					Name:         "Pay rate",
+					Type:         geckoboard.MoneyType,
+					Optional:     true,
+					CurrencyCode: "USD",
+				},
+				"client_bill_rate": {
+					Name:         "Client bill rate",
+					Type:         geckoboard.MoneyType,
+					Optional:     true,
+					CurrencyCode: "USD",
+				},
+				"spread_per_hour": {
+					Name:         "Spread per hour",
+					Type:         geckoboard.MoneyType,
+					Optional:     true,
+					CurrencyCode: "USD",
+				},
+				"gross_margin": {
+					Name:     "Gross margin",
+					Type:     geckoboard.PercentType,
+					Optional: true,
+				},
+				"hours_per_day": {
+					Name:     "Hours per day",
+					Type:     geckoboard.NumberType,
+					Optional: true,
+				},
+				"duration_weeks": {
+					Name:     "Duration weeks",
+					Type:     geckoboard.NumberType,
+					Optional: true,
+				},
+				"candidate": {
+					Name:     "Candidate",
+					Type:     geckoboard.StringType,
+					Optional: true,
+				},
+				"owner": {
+					Name:     "Owner",
+					Type:     geckoboard.StringType,
+					Optional: true,
+				},
+				"client_corporation": {
+					Name:     "Client corporation",
+					Type:     geckoboard.StringType,
+					Optional: true,
+				},
 				"custom_date_1": {
 					Type:     "datetime",
 					Name:     "Custom text 1",
@@ -345,6 +499,49 @@ func TestPlacement_Schema(t *testing.T) {
 	})
 }
 
+func TestPlacement_CurrencyCode(t *testing.T) {
+	t.Run("defaults money fields to USD", func(t *testing.T) {
+		got := (&placementProcessor{}).Schema()
+		assert.Equal(t, got.Fields["salary"].CurrencyCode, "USD")
+	})
+
+	t.Run("uses the currency code from the env", func(t *testing.T) {
+		defer os.Unsetenv("CURRENCY_CODE")
+		os.Setenv("CURRENCY_CODE", "gbp")
+
+		got := (&placementProcessor{}).Schema()
+		assert.Equal(t, got.Fields["salary"].CurrencyCode, "GBP")
+		assert.Equal(t, got.Fields["spread_per_hour"].CurrencyCode, "GBP")
+	})
+}
+
+func TestPlacement_Rates(t *testing.T) {
+	tests := []struct {
+		name              string
+		payRate, billRate float64
+		wantSpread        *int
+		wantMargin        *float64
+	}{
+		{name: "both rates set", payRate: 40, billRate: 50, wantSpread: intPtr(1000), wantMargin: floatPtr(0.2)},
+		{name: "same rates", payRate: 50, billRate: 50, wantSpread: intPtr(0), wantMargin: floatPtr(0)},
+		{name: "salaried without rates", wantSpread: nil, wantMargin: nil},
+		{name: "pay rate not set", billRate: 50, wantSpread: nil, wantMargin: nil},
+		{name: "bill rate not set", payRate: 40, wantSpread: nil, wantMargin: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.DeepEqual(t, spreadPerHour(tc.payRate, tc.billRate), tc.wantSpread)
+			assert.DeepEqual(t, grossMargin(tc.payRate, tc.billRate), tc.wantMargin)
+		})
+	}
+}
+
+func TestPlacement_MoneyOrNil(t *testing.T) {
+	assert.DeepEqual(t, moneyOrNil(0), (*int)(nil))
+	assert.DeepEqual(t, moneyOrNil(55000.5), intPtr(5500050))
+}
+
 func TestPlacement_QueryData(t *testing.T) {
 	t.Run("returns all records successfully", func(t *testing.T) {
 		bc := bullhorn.New("")
@@ -492,58 +689,88 @@ func TestPlacement_QueryData(t *testing.T) {
 			assert.NilError(t, err)
 			assert.DeepEqual(t, data, geckoboard.Data{
 				{
-					"custom_date_2":     stringPtr("2022-07-30T15:17:01Z"),
-					"custom_float_3":    float64(3),
-					"custom_text_2":     "text2",
-					"date_added":        stringPtr("2022-07-30T14:10:21Z"),
-					"date_begin":        stringPtr("2022-07-31T17:57:01Z"),
-					"date_ended":        stringPtr("2022-08-08T20:23:41Z"),
-					"employee_type":     "1",
-					"employment_type":   "Contract",
-					"fee":               float64(123),
-					"id":                "1",
-					"job_order":         "Job Title ABC (99)",
-					"onboarding_status": "Completed",
-					"referral_fee":      float64(25),
-					"referral_fee_type": "percentage",
-					"status":            "Active",
-					"updated_at":        stringPtr("2022-07-30T15:00:21Z"),
+					"custom_date_2":      stringPtr("2022-07-30T15:17:01Z"),
+					"custom_float_3":     float64(3),
+					"custom_text_2":      "text2",
+					"date_added":         stringPtr("2022-07-30T14:10:21Z"),
+					"date_begin":         stringPtr("2022-07-31T17:57:01Z"),
+					"date_ended":         stringPtr("2022-08-08T20:23:41Z"),
+					"employee_type":      "1",
+					"employment_type":    "Contract",
+					"fee":                float64(123),
+					"id":                 "1",
+					"job_order":          "Job Title ABC (99)",
+					"onboarding_status":  "Completed",
+					"referral_fee":       float64(25),
+					"referral_fee_type":  "percentage",
+					"status":             "Active",
+					"updated_at":         stringPtr("2022-07-30T15:00:21Z"),
+					"salary":             (*int)(nil),
+					"pay_rate":           intPtr(4000),
+					"client_bill_rate":   intPtr(5050),
+					"spread_per_hour":    intPtr(1050),
+					"gross_margin":       floatPtr(10.5 / 50.5),
+					"hours_per_day":      float64(8),
+					"duration_weeks":     float64(12),
+					"candidate":          stringPtr("Candidate A"),
+					"owner":              stringPtr("Owner B"),
+					"client_corporation": "GeckoOrg",
 				},
 				{
-					"custom_date_2":     stringPtr("2022-07-30T12:30:21Z"),
-					"custom_float_3":    float64(23),
-					"custom_text_2":     "text22",
-					"date_added":        stringPtr("2022-07-30T11:23:41Z"),
-					"date_begin":        stringPtr("2022-07-31T15:10:21Z"),
-					"date_ended":        stringPtr("2022-08-08T17:37:01Z"),
-					"employee_type":     "1",
-					"employment_type":   "Contract",
-					"fee":               float64(2123),
-					"id":                "2",
-					"job_order":         "Job Title CEF (299)",
-					"onboarding_status": "Completed",
-					"referral_fee":      float64(225),
-					"referral_fee_type": "percentage",
-					"status":            "Active",
-					"updated_at":        stringPtr("2022-07-30T12:13:41Z"),
+					"custom_date_2":      stringPtr("2022-07-30T12:30:21Z"),
+					"custom_float_3":     float64(23),
+					"custom_text_2":      "text22",
+					"date_added":         stringPtr("2022-07-30T11:23:41Z"),
+					"date_begin":         stringPtr("2022-07-31T15:10:21Z"),
+					"date_ended":         stringPtr("2022-08-08T17:37:01Z"),
+					"employee_type":      "1",
+					"employment_type":    "Contract",
+					"fee":                float64(2123),
+					"id":                 "2",
+					"job_order":          "Job Title CEF (299)",
+					"onboarding_status":  "Completed",
+					"referral_fee":       float64(225),
+					"referral_fee_type":  "percentage",
+					"status":             "Active",
+					"updated_at":         stringPtr("2022-07-30T12:13:41Z"),
+					"salary":             intPtr(5500050),
+					"pay_rate":           (*int)(nil),
+					"client_bill_rate":   (*int)(nil),
+					"spread_per_hour":    (*int)(nil),
+					"gross_margin":       (*float64)(nil),
+					"hours_per_day":      float64(0),
+					"duration_weeks":     float64(0),
+					"candidate":          (*string)(nil),
+					"owner":              (*string)(nil),
+					"client_corporation": "(not set)",
 				},
 				{
-					"custom_date_2":     stringPtr("2022-07-30T09:43:41Z"),
-					"custom_float_3":    float64(33),
-					"custom_text_2":     "text32",
-					"date_added":        stringPtr("2022-07-30T08:37:01Z"),
-					"date_begin":        stringPtr("2022-07-31T12:23:41Z"),
-					"date_ended":        stringPtr("2022-08-08T14:50:21Z"),
-					"employee_type":     "Contractor",
-					"employment_type":   "Contract",
-					"fee":               float64(3123),
-					"id":                "3",
-					"job_order":         "Job Title GHI (399)",
-					"onboarding_status": "Canceled",
-					"referral_fee":      float64(0),
-					"referral_fee_type": "",
-					"status":            "Terminated",
-					"updated_at":        stringPtr("2022-07-30T09:27:01Z"),
+					"custom_date_2":      stringPtr("2022-07-30T09:43:41Z"),
+					"custom_float_3":     float64(33),
+					"custom_text_2":      "text32",
+					"date_added":         stringPtr("2022-07-30T08:37:01Z"),
+					"date_begin":         stringPtr("2022-07-31T12:23:41Z"),
+					"date_ended":         stringPtr("2022-08-08T14:50:21Z"),
+					"employee_type":      "Contractor",
+					"employment_type":    "Contract",
+					"fee":                float64(3123),
+					"id":                 "3",
+					"job_order":          "Job Title GHI (399)",
+					"onboarding_status":  "Canceled",
+					"referral_fee":       float64(0),
+					"referral_fee_type":  "",
+					"status":             "Terminated",
+					"updated_at":         stringPtr("2022-07-30T09:27:01Z"),
+					"salary":             (*int)(nil),
+					"pay_rate":           (*int)(nil),
+					"client_bill_rate":   (*int)(nil),
+					"spread_per_hour":    (*int)(nil),
+					"gross_margin":       (*float64)(nil),
+					"hours_per_day":      float64(0),
+					"duration_weeks":     float64(0),
+					"candidate":          (*string)(nil),
+					"owner":              (*string)(nil),
+					"client_corporation": "(not set)",
 				},
 			})
 		})
@@ -557,7 +784,7 @@ func TestPlacement_QueryData(t *testing.T) {
 				{
 					name:    "invalid custom field name",
 					fields:  []string{"customDate2", "customField2"},
-					wantErr: `unknown placement field "customField2", only customDate0, customText0 and customFloat0 are valid`,
+					wantErr: `unknown placement field "customField2", only customDate0, customText0, customTextBlock0, customFloat0 and customInt0 are valid`,
 				},
 				{
 					name:    "custom date field over range",
@@ -608,6 +835,45 @@ func TestPlacement_QueryData(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("queries nested fields and sets the data in the dataset", func(t *testing.T) {
+		defer os.Unsetenv("PLACEMENT_NESTEDFIELDS")
+		os.Setenv("PLACEMENT_NESTEDFIELDS", "jobOrder.clientCorporation.name, candidate.source")
+
+		bc := bullhorn.New("")
+		bc.PlacementService = mockPlacementService{
+			searchFn: func(got bullhorn.SearchQuery) (*bullhorn.Placements, error) {
+				want := bullhorn.SearchQuery{
+					Fields:     append(wantPlacementFields, "jobOrder.clientCorporation.name", "candidate.source"),
+					Where:      "id>0",
+					Count:      200,
+					IncludeRaw: true,
+				}
+
+				assert.DeepEqual(t, got, want)
+				return &bullhorn.Placements{
+					Items: []bullhorn.Placement{
+						{
+							ID: 1,
+							Raw: bullhorn.RawEntity{
+								"jobOrder": map[string]interface{}{
+									"clientCorporation": map[string]interface{}{"name": "GeckoOrg"},
+								},
+								"candidate": map[string]interface{}{"source": "LinkedIn"},
+							},
+						},
+					},
+				}, nil
+			},
+		}
+
+		proc := placementProcessor{client: bc, maxDatasetRecords: 50, recordsPerPage: 200}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data[0]["job_order_client_corporation_name"], stringPtr("GeckoOrg"))
+		assert.DeepEqual(t, data[0]["candidate_source"], stringPtr("LinkedIn"))
+	})
 }
 
 type mockPlacementService struct {
@@ -4,13 +4,23 @@ import (
 	"bullhorn-to-dataset/bullhorn"
 	"bullhorn-to-dataset/geckoboard"
 	"bullhorn-to-dataset/printer"
+	"bullhorn-to-dataset/shutdown"
+	"bullhorn-to-dataset/sink"
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 )
 
 const (
 	maxRecordsPerPage = 200
 	maxDatasetRecords = 5000
+
+	datasetPrefix = "bullhorn-"
+
+	currencyCodeEnv     = "CURRENCY_CODE"
+	defaultCurrencyCode = "USD"
 )
 
 type datasetProcessor interface {
@@ -20,64 +30,380 @@ type datasetProcessor interface {
 	Schema() *geckoboard.Dataset
 }
 
-// Processor contains clients to push and pull data
+// Processor contains the client to pull data and the sinks to write it to
 type Processor struct {
-	geckoboardClient *geckoboard.Client
-	processors       []datasetProcessor
-	printer          printer.Printer
+	sinks      []sink.Sink
+	processors []datasetProcessor
+	printer    printer.Printer
+
+	// changeDetection is only set when unchanged rows should be skipped
+	changeDetection *changeDetection
+
+	// recorders are told how each push went
+	recorders []Recorder
+
+	// departments is reset at the start of each run
+	departments *ownerDepartmentCache
 }
 
-func New(bc *bullhorn.Client, gc *geckoboard.Client) Processor {
-	return Processor{
-		geckoboardClient: gc,
-		processors: []datasetProcessor{
-			jobOrderProcessor{
-				client:            bc,
-				maxDatasetRecords: maxDatasetRecords,
-				ordersPerPage:     maxRecordsPerPage,
-			},
-			&placementProcessor{
-				client:            bc,
-				maxDatasetRecords: maxDatasetRecords,
-				recordsPerPage:    maxRecordsPerPage,
-			},
-			&jobSubmissionProcessor{
-				client:            bc,
-				maxDatasetRecords: maxDatasetRecords,
-				recordsPerPage:    maxRecordsPerPage,
-			},
-			&clientContactProcessor{
-				client:            bc,
-				maxDatasetRecords: maxDatasetRecords,
-				recordsPerPage:    maxRecordsPerPage,
-			},
+// Recorder is told how each push went, such as to keep metrics of them
+type Recorder interface {
+	RecordWrite(dataset, sink string, records int)
+	RecordDataset(dataset string, duration time.Duration, ok bool)
+	// RecordCycle is called after each run of one or more datasets
+	RecordCycle(ok bool)
+}
+
+// ErrorRecorder is a Recorder which is also told why pushing a dataset
+// failed, it can be told more than one error for the same push
+type ErrorRecorder interface {
+	Recorder
+
+	RecordError(dataset string, err error)
+}
+
+// New returns a processor for each of the built in entities and the
+// custom objects in CUSTOMOBJECTS, which are checked up front
+func New(bc *bullhorn.Client, sinks []sink.Sink) (Processor, error) {
+	customObjects, err := customObjectProcessors(bc)
+	if err != nil {
+		return Processor{}, err
+	}
+
+	departments := newOwnerDepartmentCache(bc, maxRecordsPerPage)
+
+	processors := []datasetProcessor{
+		&jobOrderProcessor{
+			client:            bc,
+			maxDatasetRecords: maxDatasetRecords,
+			ordersPerPage:     maxRecordsPerPage,
+			departments:       departments,
+		},
+		&placementProcessor{
+			client:            bc,
+			maxDatasetRecords: maxDatasetRecords,
+			recordsPerPage:    maxRecordsPerPage,
+			departments:       departments,
+		},
+		&jobSubmissionProcessor{
+			client:            bc,
+			maxDatasetRecords: maxDatasetRecords,
+			recordsPerPage:    maxRecordsPerPage,
+			departments:       departments,
+		},
+		&clientContactProcessor{
+			client:            bc,
+			maxDatasetRecords: maxDatasetRecords,
+			recordsPerPage:    maxRecordsPerPage,
+			departments:       departments,
+		},
+		&jobSubmissionHistoryProcessor{
+			client:            bc,
+			maxDatasetRecords: maxDatasetRecords,
+			recordsPerPage:    maxRecordsPerPage,
+		},
+		&placementCommissionProcessor{
+			client:            bc,
+			maxDatasetRecords: maxDatasetRecords,
+			recordsPerPage:    maxRecordsPerPage,
+		},
+		&corporateUserProcessor{
+			client:            bc,
+			maxDatasetRecords: maxDatasetRecords,
+			recordsPerPage:    maxRecordsPerPage,
+		},
+		&tearsheetProcessor{
+			client:            bc,
+			maxDatasetRecords: maxDatasetRecords,
+			recordsPerPage:    maxRecordsPerPage,
 		},
-		printer: printer.LogPrinter{},
 	}
+
+	return Processor{
+		sinks:       sinks,
+		processors:  append(processors, customObjects...),
+		printer:     printer.LogPrinter{},
+		departments: departments,
+	}, nil
+}
+
+// EnableChangeDetection keeps a hash of each row pushed in the state
+// directory so only new and changed rows are pushed on the next run,
+// all rows are pushed again once the full refresh interval has passed
+func (p *Processor) EnableChangeDetection(stateDir string, fullRefreshInterval time.Duration) error {
+	store, err := newFileRowHashStore(stateDir)
+	if err != nil {
+		return err
+	}
+
+	p.changeDetection = &changeDetection{
+		store:               store,
+		fullRefreshInterval: fullRefreshInterval,
+		now:                 time.Now,
+	}
+
+	return nil
+}
+
+// PrintWith prints the progress with the printer instead of to stdout
+func (p *Processor) PrintWith(pr printer.Printer) {
+	p.printer = pr
+}
+
+// RecordWith tells the recorder how each dataset push went,
+// it can be called again to tell more than one recorder
+func (p *Processor) RecordWith(r Recorder) {
+	p.recorders = append(p.recorders, r)
 }
 
 // Process handles multiple dataset processors calling process
-// on each of them and creating the dataset for each of them and pushing data.
-// Doesn't block other processors if one of them was to fail
+// on each of them and writing the data to each of the sinks.
+// Doesn't block other processors or sinks if one of them was to fail
 func (p Processor) ProcessAll(ctx context.Context) {
+	p.process(ctx, p.processors)
+}
+
+// Process is the same as ProcessAll for only the named datasets
+func (p Processor) Process(ctx context.Context, datasets []string) {
+	selected := []datasetProcessor{}
 	for _, dp := range p.processors {
-		data, err := dp.QueryData(ctx)
-		if err != nil {
-			p.printer.Printf("Fetching data for %s failed with error: %s\n", dp, err)
-			continue
+		for _, name := range datasets {
+			if dp.Schema().Name == name {
+				selected = append(selected, dp)
+				break
+			}
 		}
+	}
 
-		dataset := dp.Schema()
-		if err := p.geckoboardClient.DatasetService.FindOrCreate(ctx, dataset); err != nil {
-			p.printer.Printf("Creating %s dataset failed with error: %s\n", dp, err)
+	p.process(ctx, selected)
+}
+
+func (p Processor) process(ctx context.Context, processors []datasetProcessor) {
+	allOK := true
+	p.departments.reset()
+
+	for _, dp := range processors {
+		if shutdown.IsStopping(ctx) {
+			p.printer.Printf("Shutting down, not pushing %s data\n", dp)
+			allOK = false
 			continue
 		}
 
-		p.printer.Printf("Pushing %d %s records to geckoboard\n", len(data), dp)
-		if err := p.geckoboardClient.DatasetService.AppendData(ctx, dataset, data); err != nil {
-			p.printer.Printf("Pushing %s data failed with error: %s\n", dp, err)
+		start := time.Now()
+		ok := p.processDataset(ctx, dp)
+		allOK = allOK && ok
+
+		for _, r := range p.recorders {
+			r.RecordDataset(dp.Schema().Name, time.Since(start), ok)
+		}
+	}
+
+	for _, r := range p.recorders {
+		r.RecordCycle(allOK)
+	}
+}
+
+// processDataset pushes the data of a single dataset to
+// every sink, returning false when any part of it failed
+func (p Processor) processDataset(ctx context.Context, dp datasetProcessor) bool {
+	dataset, data, err := p.queryDataset(ctx, dp)
+	if err != nil {
+		p.printer.Printf("Fetching data for %s failed with error: %s\n", dp, err)
+		p.recordError(dp.Schema(), fmt.Errorf("fetching data: %w", err))
+		return false
+	}
+
+	detectChanges := p.changeDetection != nil && p.hasIncrementalSink()
+
+	changed := data
+	var hashes rowHashes
+	if detectChanges {
+		var stats changeStats
+		changed, hashes, stats, err = p.changeDetection.changedRows(dataset, data)
+		if err != nil {
+			p.printer.Printf("Detecting changed %s records failed with error: %s\n", dp, err)
+			p.recordError(dataset, fmt.Errorf("detecting changed records: %w", err))
+			return false
+		}
+
+		p.printChangeStats(dp, stats)
+	}
+
+	allOK := true
+	rejected := []geckoboard.RejectedRow{}
+	for _, s := range p.sinks {
+		rows := data
+		if s.Incremental() {
+			rows = changed
+		}
+
+		result, ok := p.write(ctx, s, dp, dataset, rows)
+		allOK = allOK && ok
+
+		for _, r := range p.recorders {
+			r.RecordWrite(dataset.Name, s.String(), result.RecordsSent)
+		}
+
+		if s.Incremental() {
+			rejected = append(rejected, result.Rejected...)
+
+			// The rows have to be written again next time when any sink failed
+			detectChanges = detectChanges && ok
+		}
+	}
+
+	if detectChanges {
+		if err := p.changeDetection.save(dataset, hashes, rejected); err != nil {
+			p.printer.Printf("Saving %s record hashes failed with error: %s\n", dp, err)
+			p.recordError(dataset, fmt.Errorf("saving record hashes: %w", err))
+			return false
+		}
+	}
+
+	return allOK
+}
+
+// Datasets returns the names of the datasets each processor creates
+func (p Processor) Datasets() []string {
+	names := []string{}
+	for _, dp := range p.processors {
+		names = append(names, dp.Schema().Name)
+	}
+
+	return names
+}
+
+// Query returns the schema and data of a single dataset without writing it
+// anywhere, the bullhorn- prefix of the dataset name can be left out
+func (p Processor) Query(ctx context.Context, name string) (*geckoboard.Dataset, geckoboard.Data, error) {
+	name, err := p.DatasetName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, dp := range p.processors {
+		if dp.Schema().Name != name {
 			continue
 		}
+
+		p.departments.reset()
+		dataset, data, err := p.queryDataset(ctx, dp)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching data for %s: %w", dp, err)
+		}
+
+		return dataset, data, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown dataset %q", name)
+}
+
+// queryDataset returns the schema and data of the dataset
+// with the types of any fields overridden in ENTITY_FIELDTYPES
+func (p Processor) queryDataset(ctx context.Context, dp datasetProcessor) (*geckoboard.Dataset, geckoboard.Data, error) {
+	overrides, err := fetchFieldTypeOverrides(dp.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := dp.QueryData(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.printer.Printf("Queried %d %s records\n", len(data), dp)
+
+	// The custom and nested fields are only in the schema once the data is queried
+	dataset := dp.Schema()
+	if err := overrides.apply(dp.String(), dataset, data); err != nil {
+		return nil, nil, err
+	}
+
+	return dataset, data, nil
+}
+
+// DatasetName returns the full name of the dataset, which can be given
+// without the bullhorn- prefix or as the record type like placement
+func (p Processor) DatasetName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for _, dp := range p.processors {
+		d := dp.Schema().Name
+		if d == name || d == datasetPrefix+name {
+			return d, nil
+		}
+
+		if record := dp.String(); name == record || name == strings.ReplaceAll(record, " ", "-") {
+			return d, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown dataset %q, expected one of %s", name, strings.Join(p.Datasets(), ", "))
+}
+
+// write prepares the sink for the dataset and writes the data to it
+// logging any errors, ok is false when the data wasn't written
+func (p Processor) write(ctx context.Context, s sink.Sink, dp datasetProcessor, dataset *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, bool) {
+	if err := s.Prepare(ctx, dataset); err != nil {
+		p.printer.Printf("Creating %s dataset in %s failed with error: %s\n", dp, s, err)
+		p.recordError(dataset, fmt.Errorf("creating dataset in %s: %w", s, err))
+		return geckoboard.AppendResult{}, false
+	}
+
+	p.printer.Printf("Pushing %d %s records to %s\n", len(data), dp, s)
+	result, err := s.Write(ctx, dataset, data)
+	p.printRejectedRows(dp, result.Rejected)
+
+	if result.Skipped > 0 {
+		p.printer.Printf("Resumed pushing %s data, skipped %d batches sent by the last run\n", dp, result.Skipped)
+	}
+
+	if err != nil {
+		p.printer.Printf("Pushing %s data to %s failed with error: %s\n", dp, s, err)
+		p.recordError(dataset, fmt.Errorf("pushing to %s: %w", s, err))
+		return result, false
+	}
+
+	return result, true
+}
+
+// recordError tells the recorders which keep errors why the dataset failed
+func (p Processor) recordError(dataset *geckoboard.Dataset, err error) {
+	for _, r := range p.recorders {
+		if er, ok := r.(ErrorRecorder); ok {
+			er.RecordError(dataset.Name, err)
+		}
+	}
+}
+
+func (p Processor) hasIncrementalSink() bool {
+	for _, s := range p.sinks {
+		if s.Incremental() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p Processor) printChangeStats(dp datasetProcessor, stats changeStats) {
+	if stats.fullRefresh {
+		p.printer.Printf("Full refresh of %s, found %d new, %d changed and %d unchanged records\n", dp, stats.new, stats.changed, stats.unchanged)
+		return
+	}
+
+	p.printer.Printf("Found %d new, %d changed and %d unchanged %s records\n", stats.new, stats.changed, stats.unchanged, dp)
+}
+
+func (p Processor) printRejectedRows(dp datasetProcessor, rejected []geckoboard.RejectedRow) {
+	if len(rejected) == 0 {
+		return
+	}
+
+	p.printer.Printf("Dropped %d invalid %s records\n", len(rejected), dp)
+
+	for _, r := range rejected {
+		p.printer.Printf("Dropped %s record %s: %s\n", dp, r.ID, strings.Join(r.Reasons, ", "))
 	}
 }
 
@@ -96,3 +422,13 @@ func valueOrNil(v string) *string {
 
 	return &v
 }
+
+// currencyCode returns the ISO 4217 code money fields
+// are pushed in which can be changed by an env
+func currencyCode() string {
+	if code := os.Getenv(currencyCodeEnv); code != "" {
+		return strings.ToUpper(code)
+	}
+
+	return defaultCurrencyCode
+}
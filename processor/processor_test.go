@@ -3,10 +3,16 @@ package processor
 import (
 	"bullhorn-to-dataset/bullhorn"
 	"bullhorn-to-dataset/geckoboard"
+	"bullhorn-to-dataset/shutdown"
+	"bullhorn-to-dataset/sink"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/assert/cmp"
@@ -16,22 +22,52 @@ var defaultMockProcessor = []datasetProcessor{mockDatasetProcessor{}}
 
 func TestProcessor_New(t *testing.T) {
 	bc := &bullhorn.Client{}
-	gc := &geckoboard.Client{}
+	sinks := []sink.Sink{sink.NewGeckoboard(&geckoboard.Client{})}
 
-	p := New(bc, gc)
+	p, err := New(bc, sinks)
+	assert.NilError(t, err)
 
-	assert.Equal(t, p.geckoboardClient, gc)
-	assert.Assert(t, cmp.Len(p.processors, 4))
+	assert.Assert(t, cmp.Len(p.sinks, 1))
+	assert.Equal(t, p.sinks[0], sinks[0])
+	assert.Assert(t, cmp.Len(p.processors, 8))
 
-	_, isJobOrderProcessor := p.processors[0].(jobOrderProcessor)
+	_, isJobOrderProcessor := p.processors[0].(*jobOrderProcessor)
 	_, isPlacementProcessor := p.processors[1].(*placementProcessor)
 	_, isJobSubmissionProcessor := p.processors[2].(*jobSubmissionProcessor)
 	_, isClientContactProcessor := p.processors[3].(*clientContactProcessor)
+	_, isJobSubmissionHistoryProcessor := p.processors[4].(*jobSubmissionHistoryProcessor)
+	_, isPlacementCommissionProcessor := p.processors[5].(*placementCommissionProcessor)
+	_, isCorporateUserProcessor := p.processors[6].(*corporateUserProcessor)
+	_, isTearsheetProcessor := p.processors[7].(*tearsheetProcessor)
 
 	assert.Assert(t, isJobOrderProcessor)
 	assert.Assert(t, isPlacementProcessor)
 	assert.Assert(t, isJobSubmissionProcessor)
 	assert.Assert(t, isClientContactProcessor)
+	assert.Assert(t, isJobSubmissionHistoryProcessor)
+	assert.Assert(t, isPlacementCommissionProcessor)
+	assert.Assert(t, isCorporateUserProcessor)
+	assert.Assert(t, isTearsheetProcessor)
+
+	t.Run("adds a processor for each custom object", func(t *testing.T) {
+		defer os.Unsetenv("CUSTOMOBJECTS")
+		os.Setenv("CUSTOMOBJECTS", "PersonCustomObjectInstance1")
+
+		p, err := New(bc, sinks)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Len(p.processors, 9))
+
+		_, isCustomObjectProcessor := p.processors[8].(*customObjectProcessor)
+		assert.Assert(t, isCustomObjectProcessor)
+	})
+
+	t.Run("returns error for an unknown custom object", func(t *testing.T) {
+		defer os.Unsetenv("CUSTOMOBJECTS")
+		os.Setenv("CUSTOMOBJECTS", "PersonCustomObject1")
+
+		_, err := New(bc, sinks)
+		assert.ErrorContains(t, err, `unknown custom object "PersonCustomObject1"`)
+	})
 }
 
 func TestProcessor_ProcessAll(t *testing.T) {
@@ -53,7 +89,7 @@ func TestProcessor_ProcessAll(t *testing.T) {
 				assert.DeepEqual(t, got, want)
 				return nil
 			},
-			appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) error {
+			appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
 				dataSent = true
 
 				assert.Equal(t, len(data), 2)
@@ -69,7 +105,7 @@ func TestProcessor_ProcessAll(t *testing.T) {
 						"field3": 66,
 					},
 				})
-				return nil
+				return geckoboard.AppendResult{}, nil
 			},
 		}
 
@@ -77,6 +113,7 @@ func TestProcessor_ProcessAll(t *testing.T) {
 		proc.ProcessAll(context.Background())
 
 		assert.DeepEqual(t, logs.msgs, []string{
+			"Queried 2 mock model records\n",
 			"Pushing 2 mock model records to geckoboard\n",
 		})
 		assert.Assert(t, dataSent)
@@ -89,8 +126,8 @@ func TestProcessor_ProcessAll(t *testing.T) {
 			findOrCreateFn: func(got *geckoboard.Dataset) error {
 				return nil
 			},
-			appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) error {
-				return nil
+			appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+				return geckoboard.AppendResult{}, nil
 			},
 		}
 
@@ -122,7 +159,9 @@ func TestProcessor_ProcessAll(t *testing.T) {
 			"query mock 2",
 		})
 		assert.DeepEqual(t, logs.msgs, []string{
+			"Queried 0 mock model records\n",
 			"Pushing 0 mock model records to geckoboard\n",
+			"Queried 0 mock model records\n",
 			"Pushing 0 mock model records to geckoboard\n",
 		})
 	})
@@ -135,11 +174,11 @@ func TestProcessor_ProcessAll(t *testing.T) {
 			findOrCreateFn: func(got *geckoboard.Dataset) error {
 				return nil
 			},
-			appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) error {
+			appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
 				dataSent = true
 				assert.Equal(t, len(data), 0)
 				assert.DeepEqual(t, data, geckoboard.Data{})
-				return nil
+				return geckoboard.AppendResult{}, nil
 			},
 		}
 
@@ -153,6 +192,7 @@ func TestProcessor_ProcessAll(t *testing.T) {
 
 		proc.ProcessAll(context.Background())
 		assert.DeepEqual(t, logs.msgs, []string{
+			"Queried 0 mock model records\n",
 			"Pushing 0 mock model records to geckoboard\n",
 		})
 		assert.Assert(t, dataSent)
@@ -185,7 +225,8 @@ func TestProcessor_ProcessAll(t *testing.T) {
 		proc.ProcessAll(context.Background())
 
 		assert.DeepEqual(t, logs.msgs, []string{
-			"Creating mock model dataset failed with error: failed to create dataset\n",
+			"Queried 2 mock model records\n",
+			"Creating mock model dataset in geckoboard failed with error: failed to create dataset\n",
 		})
 	})
 
@@ -195,8 +236,8 @@ func TestProcessor_ProcessAll(t *testing.T) {
 			findOrCreateFn: func(*geckoboard.Dataset) error {
 				return nil
 			},
-			appendDataFn: func(*geckoboard.Dataset, geckoboard.Data) error {
-				return errors.New("push data error")
+			appendDataFn: func(*geckoboard.Dataset, geckoboard.Data) (geckoboard.AppendResult, error) {
+				return geckoboard.AppendResult{}, errors.New("push data error")
 			},
 		}
 
@@ -204,21 +245,74 @@ func TestProcessor_ProcessAll(t *testing.T) {
 		proc.ProcessAll(context.Background())
 
 		assert.DeepEqual(t, logs.msgs, []string{
+			"Queried 2 mock model records\n",
 			"Pushing 2 mock model records to geckoboard\n",
-			"Pushing mock model data failed with error: push data error\n",
+			"Pushing mock model data to geckoboard failed with error: push data error\n",
 		})
 	})
 }
 
+func TestProcessor_ProcessAll_RejectedRows(t *testing.T) {
+	gc := geckoboard.New("", "")
+	gc.DatasetService = mockDatasetService{
+		findOrCreateFn: func(*geckoboard.Dataset) error {
+			return nil
+		},
+		appendDataFn: func(*geckoboard.Dataset, geckoboard.Data) (geckoboard.AppendResult, error) {
+			return geckoboard.AppendResult{
+				Batches:     1,
+				Sent:        1,
+				RecordsSent: 0,
+				Rejected: []geckoboard.RejectedRow{
+					{ID: "4345", Reasons: []string{`field "id" is required`}},
+					{ID: "5555", Reasons: []string{`field "field2" must be an RFC 3339 datetime`, `unknown field "extra"`}},
+				},
+			}, nil
+		},
+	}
+
+	proc, logs := defaultNewProcessor(gc, defaultMockProcessor)
+	proc.ProcessAll(context.Background())
+
+	assert.DeepEqual(t, logs.msgs, []string{
+		"Queried 2 mock model records\n",
+		"Pushing 2 mock model records to geckoboard\n",
+		"Dropped 2 invalid mock model records\n",
+		"Dropped mock model record 4345: field \"id\" is required\n",
+		"Dropped mock model record 5555: field \"field2\" must be an RFC 3339 datetime, unknown field \"extra\"\n",
+	})
+}
+
+func TestProcessor_ProcessAll_Resumed(t *testing.T) {
+	gc := geckoboard.New("", "")
+	gc.DatasetService = mockDatasetService{
+		findOrCreateFn: func(*geckoboard.Dataset) error {
+			return nil
+		},
+		appendDataFn: func(*geckoboard.Dataset, geckoboard.Data) (geckoboard.AppendResult, error) {
+			return geckoboard.AppendResult{Batches: 3, Sent: 1, Skipped: 2, RecordsSent: 2}, nil
+		},
+	}
+
+	proc, logs := defaultNewProcessor(gc, defaultMockProcessor)
+	proc.ProcessAll(context.Background())
+
+	assert.DeepEqual(t, logs.msgs, []string{
+		"Queried 2 mock model records\n",
+		"Pushing 2 mock model records to geckoboard\n",
+		"Resumed pushing mock model data, skipped 2 batches sent by the last run\n",
+	})
+}
+
 func defaultNewProcessor(gc *geckoboard.Client, processors []datasetProcessor) (Processor, *mockLogPrinter) {
 	mockPrinter := &mockLogPrinter{
 		msgs: []string{},
 	}
 
 	return Processor{
-		processors:       processors,
-		geckoboardClient: gc,
-		printer:          mockPrinter,
+		processors: processors,
+		sinks:      []sink.Sink{sink.NewGeckoboard(gc)},
+		printer:    mockPrinter,
 	}, mockPrinter
 }
 
@@ -235,14 +329,14 @@ func (m *mockLogPrinter) Printf(format string, v ...interface{}) {
 
 type mockDatasetService struct {
 	findOrCreateFn func(*geckoboard.Dataset) error
-	appendDataFn   func(*geckoboard.Dataset, geckoboard.Data) error
+	appendDataFn   func(*geckoboard.Dataset, geckoboard.Data) (geckoboard.AppendResult, error)
 }
 
 func (m mockDatasetService) FindOrCreate(_ context.Context, dataset *geckoboard.Dataset) error {
 	return m.findOrCreateFn(dataset)
 }
 
-func (m mockDatasetService) AppendData(_ context.Context, dataset *geckoboard.Dataset, data geckoboard.Data) error {
+func (m mockDatasetService) AppendData(_ context.Context, dataset *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
 	return m.appendDataFn(dataset, data)
 }
 
@@ -302,3 +396,352 @@ func (m mockDatasetProcessor) Schema() *geckoboard.Dataset {
 		UniqueBy: []string{"id"},
 	}
 }
+
+func TestProcessor_ProcessAll_Sinks(t *testing.T) {
+	t.Run("writes to each sink when one fails", func(t *testing.T) {
+		gc := geckoboard.New("", "")
+		gc.DatasetService = mockDatasetService{
+			findOrCreateFn: func(*geckoboard.Dataset) error {
+				return errors.New("failed to create dataset")
+			},
+		}
+
+		file := &mockSink{name: "csv"}
+
+		proc, logs := defaultNewProcessor(gc, defaultMockProcessor)
+		proc.sinks = append(proc.sinks, file)
+		proc.ProcessAll(context.Background())
+
+		assert.Equal(t, len(file.written), 1)
+		assert.DeepEqual(t, logs.msgs, []string{
+			"Queried 2 mock model records\n",
+			"Creating mock model dataset in geckoboard failed with error: failed to create dataset\n",
+			"Pushing 2 mock model records to csv\n",
+		})
+	})
+
+	t.Run("writes every row to sinks which aren't incremental", func(t *testing.T) {
+		sent := []geckoboard.Data{}
+
+		gc := geckoboard.New("", "")
+		gc.DatasetService = mockDatasetService{
+			findOrCreateFn: func(*geckoboard.Dataset) error {
+				return nil
+			},
+			appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+				sent = append(sent, data)
+				return geckoboard.AppendResult{}, nil
+			},
+		}
+
+		file := &mockSink{name: "csv"}
+
+		proc, _ := defaultNewProcessor(gc, defaultMockProcessor)
+		proc.sinks = append(proc.sinks, file)
+		assert.NilError(t, proc.EnableChangeDetection(t.TempDir(), time.Hour))
+
+		proc.ProcessAll(context.Background())
+		proc.ProcessAll(context.Background())
+
+		assert.Equal(t, len(sent[1]), 0)
+		assert.Equal(t, len(file.written[1]), 2)
+	})
+}
+
+type mockSink struct {
+	name    string
+	written []geckoboard.Data
+}
+
+func (m *mockSink) String() string {
+	return m.name
+}
+
+func (m *mockSink) Prepare(context.Context, *geckoboard.Dataset) error {
+	return nil
+}
+
+func (m *mockSink) Write(_ context.Context, _ *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+	m.written = append(m.written, data)
+	return geckoboard.AppendResult{}, nil
+}
+
+func (m *mockSink) Incremental() bool {
+	return false
+}
+
+func TestProcessor_Query(t *testing.T) {
+	proc, _ := defaultNewProcessor(nil, []datasetProcessor{
+		mockDatasetProcessor{
+			schemaFn: func() *geckoboard.Dataset {
+				return &geckoboard.Dataset{Name: "bullhorn-placements"}
+			},
+		},
+		mockDatasetProcessor{
+			schemaFn: func() *geckoboard.Dataset {
+				return &geckoboard.Dataset{Name: "bullhorn-joborders"}
+			},
+			queryDataFn: func() (geckoboard.Data, error) {
+				return nil, errors.New("query failed")
+			},
+		},
+	})
+
+	assert.DeepEqual(t, proc.Datasets(), []string{"bullhorn-placements", "bullhorn-joborders"})
+
+	t.Run("returns the dataset and data", func(t *testing.T) {
+		for _, name := range []string{"bullhorn-placements", "Placements"} {
+			dataset, data, err := proc.Query(context.Background(), name)
+			assert.NilError(t, err)
+			assert.Equal(t, dataset.Name, "bullhorn-placements")
+			assert.Equal(t, len(data), 2)
+		}
+	})
+
+	t.Run("returns the custom fields in the schema", func(t *testing.T) {
+		defer os.Unsetenv("JOBORDER_CUSTOMFIELDS")
+		os.Setenv("JOBORDER_CUSTOMFIELDS", "customText2,customFloat3")
+
+		bc := bullhorn.New("")
+		bc.JobOrderService = mockJobOrderService{
+			searchFn: func(bullhorn.SearchQuery) (*bullhorn.JobOrders, error) {
+				return &bullhorn.JobOrders{Items: testJobOrders}, nil
+			},
+		}
+
+		p, err := New(bc, nil)
+		assert.NilError(t, err)
+
+		dataset, data, err := p.Query(context.Background(), "joborders")
+		assert.NilError(t, err)
+		assert.Equal(t, len(data), 3)
+
+		buf := &bytes.Buffer{}
+		assert.NilError(t, sink.Encode(buf, sink.CSVFormat, dataset, data))
+
+		header := strings.SplitN(buf.String(), "\n", 2)[0]
+		assert.Assert(t, cmp.Contains(header, "custom_text_2"))
+		assert.Assert(t, cmp.Contains(header, "custom_float_3"))
+	})
+
+	t.Run("returns error when the query fails", func(t *testing.T) {
+		_, _, err := proc.Query(context.Background(), "joborders")
+		assert.Error(t, err, "fetching data for mock model: query failed")
+	})
+
+	t.Run("returns error for an unknown dataset", func(t *testing.T) {
+		_, _, err := proc.Query(context.Background(), "candidates")
+		assert.Error(t, err, `unknown dataset "candidates", expected one of bullhorn-placements, bullhorn-joborders`)
+	})
+}
+
+func TestProcessor_Process(t *testing.T) {
+	pushed := []string{}
+
+	gc := geckoboard.New("", "")
+	gc.DatasetService = mockDatasetService{
+		findOrCreateFn: func(*geckoboard.Dataset) error {
+			return nil
+		},
+		appendDataFn: func(dataset *geckoboard.Dataset, _ geckoboard.Data) (geckoboard.AppendResult, error) {
+			pushed = append(pushed, dataset.Name)
+			return geckoboard.AppendResult{}, nil
+		},
+	}
+
+	named := func(name string) datasetProcessor {
+		return mockDatasetProcessor{
+			schemaFn: func() *geckoboard.Dataset {
+				return &geckoboard.Dataset{Name: name}
+			},
+		}
+	}
+
+	proc, _ := defaultNewProcessor(gc, []datasetProcessor{
+		named("bullhorn-contacts"),
+		named("bullhorn-placements"),
+		named("bullhorn-tearsheets"),
+	})
+	proc.Process(context.Background(), []string{"bullhorn-tearsheets", "bullhorn-contacts"})
+
+	assert.DeepEqual(t, pushed, []string{"bullhorn-contacts", "bullhorn-tearsheets"})
+
+	name, err := proc.DatasetName("Placements")
+	assert.NilError(t, err)
+	assert.Equal(t, name, "bullhorn-placements")
+}
+
+func TestProcessor_DatasetName(t *testing.T) {
+	proc, err := New(nil, nil)
+	assert.NilError(t, err)
+
+	names := map[string]string{
+		"bullhorn-placements": "bullhorn-placements",
+		"placements":          "bullhorn-placements",
+		"Placement":           "bullhorn-placements",
+		"job-submission":      "bullhorn-job-submissions",
+		"job submission":      "bullhorn-job-submissions",
+	}
+
+	for name, want := range names {
+		got, err := proc.DatasetName(name)
+		assert.NilError(t, err)
+		assert.Equal(t, got, want)
+	}
+
+	_, err = proc.DatasetName("placed")
+	assert.ErrorContains(t, err, `unknown dataset "placed"`)
+}
+
+func TestProcessor_ProcessAll_ShuttingDown(t *testing.T) {
+	ctx, stop := shutdown.WithStop(context.Background())
+
+	gc := geckoboard.New("", "")
+	gc.DatasetService = mockDatasetService{
+		findOrCreateFn: func(*geckoboard.Dataset) error {
+			return nil
+		},
+		appendDataFn: func(*geckoboard.Dataset, geckoboard.Data) (geckoboard.AppendResult, error) {
+			stop()
+			return geckoboard.AppendResult{}, nil
+		},
+	}
+
+	proc, logs := defaultNewProcessor(gc, []datasetProcessor{mockDatasetProcessor{}, mockDatasetProcessor{}})
+	proc.ProcessAll(ctx)
+
+	assert.DeepEqual(t, logs.msgs, []string{
+		"Queried 2 mock model records\n",
+		"Pushing 2 mock model records to geckoboard\n",
+		"Shutting down, not pushing mock model data\n",
+	})
+}
+
+func TestProcessor_RecordWith(t *testing.T) {
+	gc := geckoboard.New("", "")
+	gc.DatasetService = mockDatasetService{
+		findOrCreateFn: func(*geckoboard.Dataset) error {
+			return nil
+		},
+		appendDataFn: func(_ *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+			return geckoboard.AppendResult{RecordsSent: len(data)}, nil
+		},
+	}
+
+	failing := mockDatasetProcessor{
+		schemaFn: func() *geckoboard.Dataset {
+			return &geckoboard.Dataset{Name: "failing"}
+		},
+		queryDataFn: func() (geckoboard.Data, error) {
+			return nil, errors.New("query failed")
+		},
+	}
+
+	recorder, other := &mockRecorder{}, &mockRecorder{}
+	proc, _ := defaultNewProcessor(gc, []datasetProcessor{mockDatasetProcessor{}, failing})
+	proc.RecordWith(recorder)
+	proc.RecordWith(other)
+	proc.ProcessAll(context.Background())
+
+	assert.DeepEqual(t, recorder.records, []string{
+		"write mock-model geckoboard 2",
+		"dataset mock-model true",
+		"dataset failing false",
+		"cycle false",
+	})
+	assert.DeepEqual(t, other.records, recorder.records)
+}
+
+func TestProcessor_ProcessAll_SchemaAfterQuery(t *testing.T) {
+	created := []*geckoboard.Dataset{}
+
+	gc := geckoboard.New("", "")
+	gc.DatasetService = mockDatasetService{
+		findOrCreateFn: func(dataset *geckoboard.Dataset) error {
+			created = append(created, dataset)
+			return nil
+		},
+		appendDataFn: func(*geckoboard.Dataset, geckoboard.Data) (geckoboard.AppendResult, error) {
+			return geckoboard.AppendResult{}, nil
+		},
+	}
+
+	// Like the custom fields, the field is only in the schema once the data is queried
+	queried := false
+	dp := mockDatasetProcessor{
+		queryDataFn: func() (geckoboard.Data, error) {
+			queried = true
+			return geckoboard.Data{{"custom_text_1": "a"}}, nil
+		},
+		schemaFn: func() *geckoboard.Dataset {
+			dataset := &geckoboard.Dataset{Name: "mock-model", Fields: map[string]geckoboard.Field{}}
+			if queried {
+				dataset.Fields["custom_text_1"] = geckoboard.Field{Name: "Custom text 1", Type: geckoboard.StringType}
+			}
+
+			return dataset
+		},
+	}
+
+	proc, _ := defaultNewProcessor(gc, []datasetProcessor{dp})
+	proc.ProcessAll(context.Background())
+
+	assert.Assert(t, cmp.Len(created, 1))
+	assert.Assert(t, cmp.Contains(created[0].Fields, "custom_text_1"))
+}
+
+func TestProcessor_RecordWith_Errors(t *testing.T) {
+	gc := geckoboard.New("", "")
+	gc.DatasetService = mockDatasetService{
+		findOrCreateFn: func(*geckoboard.Dataset) error {
+			return nil
+		},
+		appendDataFn: func(*geckoboard.Dataset, geckoboard.Data) (geckoboard.AppendResult, error) {
+			return geckoboard.AppendResult{}, errors.New("rate limited")
+		},
+	}
+
+	failing := mockDatasetProcessor{
+		schemaFn: func() *geckoboard.Dataset {
+			return &geckoboard.Dataset{Name: "failing"}
+		},
+		queryDataFn: func() (geckoboard.Data, error) {
+			return nil, errors.New("query failed")
+		},
+	}
+
+	recorder := &mockErrorRecorder{}
+	proc, _ := defaultNewProcessor(gc, []datasetProcessor{mockDatasetProcessor{}, failing})
+	proc.RecordWith(recorder)
+	proc.ProcessAll(context.Background())
+
+	assert.DeepEqual(t, recorder.errors, []string{
+		"mock-model pushing to geckoboard: rate limited",
+		"failing fetching data: query failed",
+	})
+}
+
+type mockErrorRecorder struct {
+	mockRecorder
+	errors []string
+}
+
+func (m *mockErrorRecorder) RecordError(dataset string, err error) {
+	m.errors = append(m.errors, fmt.Sprintf("%s %s", dataset, err))
+}
+
+type mockRecorder struct {
+	records []string
+}
+
+func (m *mockRecorder) RecordWrite(dataset, sink string, records int) {
+	m.records = append(m.records, fmt.Sprintf("write %s %s %d", dataset, sink, records))
+}
+
+func (m *mockRecorder) RecordDataset(dataset string, _ time.Duration, ok bool) {
+	m.records = append(m.records, fmt.Sprintf("dataset %s %t", dataset, ok))
+}
+
+func (m *mockRecorder) RecordCycle(ok bool) {
+	m.records = append(m.records, fmt.Sprintf("cycle %t", ok))
+}
@@ -0,0 +1,147 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type tearsheetProcessor struct {
+	client *bullhorn.Client
+
+	maxDatasetRecords int
+	recordsPerPage    int
+}
+
+func (tearsheetProcessor) String() string {
+	return "tearsheet"
+}
+
+func (t *tearsheetProcessor) QueryData(ctx context.Context) (geckoboard.Data, error) {
+	tearsheets, err := t.queryTearsheets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIndex := int(math.Min(float64(len(tearsheets)), float64(t.maxDatasetRecords)))
+	latestTearsheets := tearsheets[0:maxIndex]
+
+	data := geckoboard.Data{}
+	for _, ts := range latestTearsheets {
+		var contacts, jobOrders []string
+		for _, c := range ts.ClientContacts.Data {
+			contacts = append(contacts, c.Name)
+		}
+
+		for _, j := range ts.JobOrders.Data {
+			jobOrders = append(jobOrders, fmt.Sprintf("%s (%d)", j.Title, j.ID))
+		}
+
+		entry := geckoboard.DataRow{
+			"id":                   strconv.Itoa(ts.ID),
+			"name":                 ts.Name,
+			"owner":                ts.Owner.FullName(),
+			"member_count":         ts.Candidates.Total,
+			"client_contact_count": ts.ClientContacts.Total,
+			"client_contacts":      valueOrNotSet(strings.Join(contacts, " ; ")),
+			"job_order_count":      ts.JobOrders.Total,
+			"job_orders":           valueOrNotSet(strings.Join(jobOrders, " ; ")),
+			"private":              strings.ToUpper(strconv.FormatBool(ts.IsPrivate)),
+			"date_added":           valueOrNil(ts.DateAdded.String()),
+		}
+
+		data = append(data, entry)
+	}
+
+	return data, nil
+}
+
+func (t *tearsheetProcessor) Schema() *geckoboard.Dataset {
+	return &geckoboard.Dataset{
+		Name: "bullhorn-tearsheets",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"name": {
+				Name:     "Name",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"owner": {
+				Name:     "Owner",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"member_count": {
+				Name:     "Member count",
+				Type:     geckoboard.NumberType,
+				Optional: true,
+			},
+			"client_contact_count": {
+				Name:     "Client contact count",
+				Type:     geckoboard.NumberType,
+				Optional: true,
+			},
+			"client_contacts": {
+				Name:     "Client contacts",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"job_order_count": {
+				Name:     "Job order count",
+				Type:     geckoboard.NumberType,
+				Optional: true,
+			},
+			"job_orders": {
+				Name:     "Job orders",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"private": {
+				Name:     "Private",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"date_added": {
+				Name: "Date added", Type: geckoboard.DatetimeType,
+				Optional: true,
+			},
+		},
+		UniqueBy: []string{"id"},
+	}
+}
+
+func (t *tearsheetProcessor) queryTearsheets(ctx context.Context) ([]bullhorn.Tearsheet, error) {
+	var tearsheets []bullhorn.Tearsheet
+
+	query := bullhorn.SearchQuery{
+		Fields: []string{
+			"id", "dateAdded", "name", "owner", "isPrivate", "candidates(id)",
+			"clientContacts(id,name)", "jobOrders(id,title)",
+		},
+		Where: "isDeleted=false",
+		Start: 0,
+		Count: t.recordsPerPage,
+	}
+
+	for {
+		ts, err := t.client.TearsheetService.Search(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		tearsheets = append(tearsheets, ts.Items...)
+		if len(ts.Items) < query.Count || len(tearsheets) >= t.maxDatasetRecords {
+			return tearsheets, nil
+		}
+
+		query.Start = query.Count + query.Start
+	}
+}
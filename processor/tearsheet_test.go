@@ -0,0 +1,255 @@
+package processor
+
+import (
+	"bullhorn-to-dataset/bullhorn"
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var (
+	wantTearsheetFields = []string{
+		"id", "dateAdded", "name", "owner", "isPrivate", "candidates(id)",
+		"clientContacts(id,name)", "jobOrders(id,title)",
+	}
+
+	wantTearsheetData = geckoboard.Data{
+		{
+			"id":                   "2",
+			"name":                 "Java developers",
+			"owner":                stringPtr("User A"),
+			"member_count":         8,
+			"client_contact_count": 2,
+			"client_contacts":      "Contact A ; Contact B",
+			"job_order_count":      1,
+			"job_orders":           "Java developer (5)",
+			"private":              "FALSE",
+			"date_added":           stringPtr("2022-07-30T14:10:21Z"),
+		},
+		{
+			"id":                   "1",
+			"name":                 "Private list",
+			"owner":                (*string)(nil),
+			"member_count":         0,
+			"client_contact_count": 0,
+			"client_contacts":      "(not set)",
+			"job_order_count":      0,
+			"job_orders":           "(not set)",
+			"private":              "TRUE",
+			"date_added":           stringPtr("2022-07-30T11:23:41Z"),
+		},
+	}
+
+	testTearsheets = []bullhorn.Tearsheet{
+		{
+			ID:         2,
+			DateAdded:  1659190221000,
+			Name:       "Java developers",
+			Owner:      bullhorn.Person{FirstName: "User", LastName: "A"},
+			Candidates: bullhorn.ToMany{Total: 8},
+			ClientContacts: bullhorn.ToMany{
+				Total: 2,
+				Data: []bullhorn.NestedEntity{
+					{ID: 3, Name: "Contact A"},
+					{ID: 4, Name: "Contact B"},
+				},
+			},
+			JobOrders: bullhorn.ToMany{
+				Total: 1,
+				Data:  []bullhorn.NestedEntity{{ID: 5, Title: "Java developer"}},
+			},
+		},
+		{
+			ID:        1,
+			DateAdded: 1659180221000,
+			Name:      "Private list",
+			IsPrivate: true,
+		},
+	}
+)
+
+func TestTearsheet_String(t *testing.T) {
+	p := tearsheetProcessor{}
+	assert.Equal(t, p.String(), "tearsheet")
+}
+
+func TestTearsheet_Schema(t *testing.T) {
+	got := (&tearsheetProcessor{}).Schema()
+	want := &geckoboard.Dataset{
+		Name: "bullhorn-tearsheets",
+		Fields: map[string]geckoboard.Field{
+			"id": {
+				Name:     "ID",
+				Type:     geckoboard.StringType,
+				Optional: false,
+			},
+			"name": {
+				Name:     "Name",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"owner": {
+				Name:     "Owner",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"member_count": {
+				Name:     "Member count",
+				Type:     geckoboard.NumberType,
+				Optional: true,
+			},
+			"client_contact_count": {
+				Name:     "Client contact count",
+				Type:     geckoboard.NumberType,
+				Optional: true,
+			},
+			"client_contacts": {
+				Name:     "Client contacts",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"job_order_count": {
+				Name:     "Job order count",
+				Type:     geckoboard.NumberType,
+				Optional: true,
+			},
+			"job_orders": {
+				Name:     "Job orders",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"private": {
+				Name:     "Private",
+				Type:     geckoboard.StringType,
+				Optional: true,
+			},
+			"date_added": {
+				Name: "Date added", Type: geckoboard.DatetimeType,
+				Optional: true,
+			},
+		},
+		UniqueBy: []string{"id"},
+	}
+
+	assert.DeepEqual(t, got, want)
+}
+
+func TestTearsheet_QueryData(t *testing.T) {
+	t.Run("returns all records successfully", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.TearsheetService = newTearsheetService(t, testTearsheets)
+
+		proc := tearsheetProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantTearsheetData)
+	})
+
+	t.Run("paginates until records are less than the count", func(t *testing.T) {
+		bullhornRequests := 0
+
+		bc := bullhorn.New("")
+		bc.TearsheetService = mockTearsheetService{
+			searchFn: func(got bullhorn.SearchQuery) (*bullhorn.Tearsheets, error) {
+				bullhornRequests += 1
+				want := bullhorn.SearchQuery{
+					Fields: wantTearsheetFields,
+					Where:  "isDeleted=false",
+					Count:  1,
+				}
+
+				switch bullhornRequests {
+				case 1:
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.Tearsheets{Items: testTearsheets[:1]}, nil
+				case 2:
+					want.Start = 1
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.Tearsheets{Items: testTearsheets[1:]}, nil
+				case 3:
+					want.Start = 2
+					assert.DeepEqual(t, got, want)
+					return &bullhorn.Tearsheets{}, nil
+				}
+
+				return nil, errors.New("shouldn't have got here")
+			},
+		}
+
+		proc := tearsheetProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    1,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantTearsheetData)
+	})
+
+	t.Run("returns only the max dataset records", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.TearsheetService = newTearsheetService(t, testTearsheets)
+
+		proc := tearsheetProcessor{
+			client:            bc,
+			maxDatasetRecords: 1,
+			recordsPerPage:    200,
+		}
+
+		data, err := proc.QueryData(context.Background())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, data, wantTearsheetData[:1])
+	})
+
+	t.Run("returns error when tearsheet query fails", func(t *testing.T) {
+		bc := bullhorn.New("")
+		bc.TearsheetService = mockTearsheetService{
+			searchFn: func(bullhorn.SearchQuery) (*bullhorn.Tearsheets, error) {
+				return nil, errors.New("query tearsheets failed")
+			},
+		}
+
+		proc := tearsheetProcessor{
+			client:            bc,
+			maxDatasetRecords: 50,
+			recordsPerPage:    200,
+		}
+
+		_, err := proc.QueryData(context.Background())
+		assert.Error(t, err, "query tearsheets failed")
+	})
+}
+
+type mockTearsheetService struct {
+	searchFn func(bullhorn.SearchQuery) (*bullhorn.Tearsheets, error)
+}
+
+func newTearsheetService(t *testing.T, recs []bullhorn.Tearsheet) mockTearsheetService {
+	return mockTearsheetService{
+		searchFn: func(got bullhorn.SearchQuery) (*bullhorn.Tearsheets, error) {
+			want := bullhorn.SearchQuery{
+				Fields: wantTearsheetFields,
+				Where:  "isDeleted=false",
+				Count:  200,
+			}
+
+			assert.DeepEqual(t, got, want)
+			return &bullhorn.Tearsheets{
+				Items: recs,
+			}, nil
+		},
+	}
+}
+
+func (m mockTearsheetService) Search(_ context.Context, query bullhorn.SearchQuery) (*bullhorn.Tearsheets, error) {
+	return m.searchFn(query)
+}
@@ -0,0 +1,188 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField is the range of values allowed in each field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// Cron is a standard 5 field cron expression of minute, hour, day of month,
+// month and day of week. Each field can be *, a number, a range like 1-5,
+// a list like 1,3,5 and have a step like */15. Sunday is 0 or 7
+type Cron struct {
+	expr                     string
+	minute, hour, dom, month uint64
+	dow                      uint64
+	domWildcard, dowWildcard bool
+}
+
+// ParseCron returns an error when the expression isn't a valid 5 field one
+func ParseCron(expr string) (*Cron, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q, expected 5 fields of minute hour day month weekday", expr)
+	}
+
+	sets := make([]uint64, len(parts))
+	for i, p := range parts {
+		set, err := parseCronField(p, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+
+		sets[i] = set
+	}
+
+	dow := sets[4]
+	// 7 is Sunday as well as 0
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+
+	return &Cron{
+		expr:        expr,
+		minute:      sets[0],
+		hour:        sets[1],
+		dom:         sets[2],
+		month:       sets[3],
+		dow:         dow,
+		domWildcard: strings.HasPrefix(parts[2], "*"),
+		dowWildcard: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseCronField returns a bit set of the values the field matches
+func parseCronField(s string, f cronField) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(s, ",") {
+		rng, step := part, 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, part)
+			}
+
+			rng, step = part[:i], n
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+
+			var err error
+			if start, err = cronValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+
+			if end, err = cronValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+
+			if start > end {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rng)
+			}
+		default:
+			n, err := cronValue(rng, f)
+			if err != nil {
+				return 0, err
+			}
+
+			// A single value with a step runs from the value to the end, like 5/15
+			start, end = n, n
+			if step > 1 {
+				end = f.max
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func cronValue(s string, f cronField) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid %s %q, expected %d-%d", f.name, s, f.min, f.max)
+	}
+
+	return n, nil
+}
+
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// Next returns the first minute after t the expression matches, in the
+// location of t. It returns the zero time when it never matches, like
+// on the 30th of February
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// Every combination of days repeats within a few years
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !has(c.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchesDay follows cron in matching either the day of month or the day
+// of week when both are restricted, otherwise the restricted one
+func (c *Cron) matchesDay(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+
+	if c.domWildcard || c.dowWildcard {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
@@ -0,0 +1,72 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	specs := []struct {
+		expr string
+		err  string
+	}{
+		{expr: "*/5 * * * *"},
+		{expr: "0 7-19 * * 1-5"},
+		{expr: "0,30 9 1,15 */2 7"},
+		{expr: "5/15 * * * *"},
+		{expr: "* * * *", err: `invalid cron expression "* * * *", expected 5 fields of minute hour day month weekday`},
+		{expr: "60 * * * *", err: `invalid cron expression "60 * * * *": invalid minute "60", expected 0-59`},
+		{expr: "* 19-7 * * *", err: `invalid cron expression "* 19-7 * * *": invalid hour range "19-7"`},
+		{expr: "*/0 * * * *", err: `invalid cron expression "*/0 * * * *": invalid minute step "*/0"`},
+		{expr: "* * 0 * *", err: `invalid cron expression "* * 0 * *": invalid day of month "0", expected 1-31`},
+		{expr: "* * * jan *", err: `invalid cron expression "* * * jan *": invalid month "jan", expected 1-12`},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.expr, func(t *testing.T) {
+			c, err := ParseCron(spec.expr)
+			if spec.err != "" {
+				assert.Error(t, err, spec.err)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, c.String(), spec.expr)
+		})
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2022, 6, 1, 10, 2, 30, 0, time.UTC)
+
+	specs := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2022, 6, 1, 10, 3, 0, 0, time.UTC)},
+		{expr: "*/5 * * * *", want: time.Date(2022, 6, 1, 10, 5, 0, 0, time.UTC)},
+		{expr: "0 * * * *", want: time.Date(2022, 6, 1, 11, 0, 0, 0, time.UTC)},
+		{expr: "*/15 7-19 * * *", want: time.Date(2022, 6, 1, 10, 15, 0, 0, time.UTC)},
+		{expr: "0 7 * * *", want: time.Date(2022, 6, 2, 7, 0, 0, 0, time.UTC)},
+		{expr: "0 7 * * 1-5", want: time.Date(2022, 6, 2, 7, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 0", want: time.Date(2022, 6, 5, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 7", want: time.Date(2022, 6, 5, 9, 0, 0, 0, time.UTC)},
+		{expr: "30 8 1 * *", want: time.Date(2022, 7, 1, 8, 30, 0, 0, time.UTC)},
+		{expr: "0 0 1 1 *", want: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Either the day of month or week matches when both are set
+		{expr: "0 9 15 * 5", want: time.Date(2022, 6, 3, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.expr, func(t *testing.T) {
+			c, err := ParseCron(spec.expr)
+			assert.NilError(t, err)
+			assert.Equal(t, c.Next(now), spec.want)
+		})
+	}
+}
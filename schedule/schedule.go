@@ -0,0 +1,301 @@
+package schedule
+
+import (
+	"bullhorn-to-dataset/printer"
+	"bullhorn-to-dataset/shutdown"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schedule decides when a dataset is next pushed
+type Schedule interface {
+	fmt.Stringer
+
+	// Next returns the time of the next run after t, zero when there is none
+	Next(t time.Time) time.Time
+}
+
+// Interval runs again the duration after the last run finished
+type Interval time.Duration
+
+func (i Interval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+func (i Interval) String() string {
+	return "every " + time.Duration(i).String()
+}
+
+// Parse returns an interval for a duration like 5m or 1h,
+// otherwise the spec is parsed as a cron expression
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if d, err := time.ParseDuration(spec); err == nil {
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid interval %q, must be more than zero", spec)
+		}
+
+		return Interval(d), nil
+	}
+
+	return ParseCron(spec)
+}
+
+// Clock is the time the scheduler runs on, swapped out in tests
+type Clock interface {
+	Now() time.Time
+	// After returns a channel which receives once the duration has passed
+	After(time.Duration) <-chan time.Time
+}
+
+// RealClock is the system clock
+type RealClock struct{}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type entry struct {
+	dataset  string
+	schedule Schedule
+	next     time.Time
+}
+
+// Scheduler runs each dataset on its own schedule,
+// or straight away when a sync is triggered
+type Scheduler struct {
+	entries []*entry
+	clock   Clock
+	printer printer.Printer
+
+	// Datasets triggered since the last run
+	mu          sync.Mutex
+	triggered   map[string]bool
+	triggerAll  bool
+	triggerWake chan struct{}
+}
+
+// New schedules every dataset on the default schedule unless it has its
+// own in the overrides, which are keyed by the full dataset name
+func New(datasets []string, def Schedule, overrides map[string]Schedule, clock Clock, p printer.Printer) *Scheduler {
+	entries := []*entry{}
+
+	for _, d := range datasets {
+		s := def
+		if o, ok := overrides[d]; ok {
+			s = o
+		}
+
+		entries = append(entries, &entry{dataset: d, schedule: s})
+	}
+
+	return &Scheduler{
+		entries:     entries,
+		clock:       clock,
+		printer:     p,
+		triggered:   map[string]bool{},
+		triggerWake: make(chan struct{}, 1),
+	}
+}
+
+// Trigger runs the datasets as soon as the run going now has finished,
+// or straight away when there isn't one. Triggers made before they start
+// are coalesced into a single run. Without any datasets every
+// dataset is run. Datasets not being scheduled are ignored
+func (s *Scheduler) Trigger(datasets []string) {
+	s.mu.Lock()
+	if len(datasets) == 0 {
+		s.triggerAll = true
+	}
+
+	for _, d := range datasets {
+		s.triggered[d] = true
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.triggerWake <- struct{}{}:
+	default:
+	}
+}
+
+// takeTriggered returns the entries triggered since the last run
+func (s *Scheduler) takeTriggered() []*entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	taken := []*entry{}
+	for _, e := range s.entries {
+		if s.triggerAll || s.triggered[e.dataset] {
+			taken = append(taken, e)
+		}
+	}
+
+	s.triggerAll = false
+	s.triggered = map[string]bool{}
+
+	return taken
+}
+
+// Run calls run with the datasets due each time some are, until the app
+// starts shutting down. Datasets on an interval run straight away, the ones
+// on a cron expression wait for the next time it matches. Runs never overlap
+// as they are only started from here one after another
+func (s *Scheduler) Run(ctx context.Context, run func(context.Context, []string)) error {
+	now := s.clock.Now()
+	for _, e := range s.entries {
+		e.next = now
+		if _, isInterval := e.schedule.(Interval); !isInterval {
+			e.next = e.schedule.Next(now)
+		}
+
+		s.printNext(e)
+	}
+
+	for {
+		if shutdown.IsStopping(ctx) {
+			return shutdown.ErrStopping
+		}
+
+		now := s.clock.Now()
+		if due := s.due(now); len(due) > 0 {
+			run(ctx, entryNames(due))
+
+			finished := s.clock.Now()
+			for _, e := range due {
+				e.next = e.schedule.Next(finished)
+				s.printNext(e)
+			}
+
+			continue
+		}
+
+		// Syncs triggered during the last run go ahead of waiting
+		select {
+		case <-s.triggerWake:
+			s.markTriggeredDue(now)
+			continue
+		default:
+		}
+
+		// A nil timer never fires, leaving only triggers to wake up for
+		var timer <-chan time.Time
+		if wake, ok := s.nextRun(); ok {
+			timer = s.clock.After(wake.Sub(now))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-shutdown.Stopping(ctx):
+			return shutdown.ErrStopping
+		case <-timer:
+		case <-s.triggerWake:
+			s.markTriggeredDue(now)
+		}
+	}
+}
+
+// markTriggeredDue makes the triggered datasets due now
+func (s *Scheduler) markTriggeredDue(now time.Time) {
+	triggered := s.takeTriggered()
+	if len(triggered) == 0 {
+		return
+	}
+
+	s.printer.Printf("Running sync of %s\n", strings.Join(entryNames(triggered), ", "))
+	for _, e := range triggered {
+		e.next = now
+	}
+}
+
+func entryNames(entries []*entry) []string {
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.dataset)
+	}
+
+	return names
+}
+
+// LongestGap returns the longest time between two runs of any dataset,
+// going through the runs of cron expressions over the next year from the
+// time. It's zero when none of the datasets run again
+func (s *Scheduler) LongestGap(from time.Time) time.Duration {
+	longest := time.Duration(0)
+	for _, e := range s.entries {
+		if gap := longestGap(e.schedule, from); gap > longest {
+			longest = gap
+		}
+	}
+
+	return longest
+}
+
+func longestGap(schedule Schedule, from time.Time) time.Duration {
+	if i, ok := schedule.(Interval); ok {
+		return time.Duration(i)
+	}
+
+	longest := time.Duration(0)
+	until := from.AddDate(1, 0, 0)
+
+	for prev := schedule.Next(from); !prev.IsZero() && prev.Before(until); {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+
+		if gap := next.Sub(prev); gap > longest {
+			longest = gap
+		}
+
+		prev = next
+	}
+
+	return longest
+}
+
+func (s *Scheduler) due(now time.Time) []*entry {
+	due := []*entry{}
+	for _, e := range s.entries {
+		if !e.next.IsZero() && !e.next.After(now) {
+			due = append(due, e)
+		}
+	}
+
+	return due
+}
+
+// nextRun returns the earliest time any of the datasets run next
+func (s *Scheduler) nextRun() (time.Time, bool) {
+	var next time.Time
+	for _, e := range s.entries {
+		if e.next.IsZero() {
+			continue
+		}
+
+		if next.IsZero() || e.next.Before(next) {
+			next = e.next
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+func (s *Scheduler) printNext(e *entry) {
+	if e.next.IsZero() {
+		s.printer.Printf("No next run of %s for %s\n", e.dataset, e.schedule)
+		return
+	}
+
+	s.printer.Printf("Next run of %s at %s (%s)\n", e.dataset, e.next.Format(time.RFC3339), e.schedule)
+}
@@ -0,0 +1,241 @@
+package schedule
+
+import (
+	"bullhorn-to-dataset/shutdown"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse("5m")
+	assert.NilError(t, err)
+	assert.Equal(t, s, Interval(5*time.Minute))
+	assert.Equal(t, s.String(), "every 5m0s")
+
+	s, err = Parse(" 0 * * * * ")
+	assert.NilError(t, err)
+	assert.Equal(t, s.String(), "0 * * * *")
+
+	_, err = Parse("-5m")
+	assert.Error(t, err, `invalid interval "-5m", must be more than zero`)
+
+	_, err = Parse("hourly")
+	assert.ErrorContains(t, err, `invalid cron expression "hourly"`)
+}
+
+func TestScheduler_Run(t *testing.T) {
+	start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("runs each dataset on its own schedule", func(t *testing.T) {
+		clock := &fakeClock{now: start}
+		logs := &mockLogPrinter{}
+
+		hourly, err := ParseCron("0 * * * *")
+		assert.NilError(t, err)
+
+		s := New(
+			[]string{"bullhorn-contacts", "bullhorn-job-submissions", "bullhorn-placements"},
+			Interval(15*time.Minute),
+			map[string]Schedule{
+				"bullhorn-contacts":        hourly,
+				"bullhorn-job-submissions": Interval(5 * time.Minute),
+			},
+			clock,
+			logs,
+		)
+
+		runs := []string{}
+		ctx, stop := shutdown.WithStop(context.Background())
+
+		err = s.Run(ctx, func(_ context.Context, datasets []string) {
+			runs = append(runs, fmt.Sprintf("%s %s", clock.now.Format("15:04"), strings.Join(datasets, ",")))
+
+			// Each run takes a minute
+			clock.now = clock.now.Add(time.Minute)
+
+			if len(runs) == 6 {
+				stop()
+			}
+		})
+		assert.ErrorIs(t, err, shutdown.ErrStopping)
+
+		assert.DeepEqual(t, runs, []string{
+			"10:00 bullhorn-job-submissions,bullhorn-placements",
+			"10:06 bullhorn-job-submissions",
+			"10:12 bullhorn-job-submissions",
+			"10:16 bullhorn-placements",
+			"10:18 bullhorn-job-submissions",
+			"10:24 bullhorn-job-submissions",
+		})
+
+		assert.DeepEqual(t, logs.msgs[:4], []string{
+			"Next run of bullhorn-contacts at 2022-06-01T11:00:00Z (0 * * * *)\n",
+			"Next run of bullhorn-job-submissions at 2022-06-01T10:00:00Z (every 5m0s)\n",
+			"Next run of bullhorn-placements at 2022-06-01T10:00:00Z (every 15m0s)\n",
+			"Next run of bullhorn-job-submissions at 2022-06-01T10:06:00Z (every 5m0s)\n",
+		})
+	})
+
+	t.Run("waits for a trigger when no dataset has another run", func(t *testing.T) {
+		never, err := ParseCron("0 0 30 2 *")
+		assert.NilError(t, err)
+
+		logs := &mockLogPrinter{}
+		s := New([]string{"bullhorn-contacts"}, never, nil, &fakeClock{now: start}, logs)
+		ctx, stop := shutdown.WithStop(context.Background())
+
+		go func() {
+			s.Trigger(nil)
+		}()
+
+		runs := 0
+		err = s.Run(ctx, func(context.Context, []string) {
+			runs++
+			stop()
+		})
+		assert.ErrorIs(t, err, shutdown.ErrStopping)
+		assert.Equal(t, runs, 1)
+		assert.DeepEqual(t, logs.msgs, []string{
+			"No next run of bullhorn-contacts for 0 0 30 2 *\n",
+			"Running sync of bullhorn-contacts\n",
+			"No next run of bullhorn-contacts for 0 0 30 2 *\n",
+		})
+	})
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("runs triggered datasets once after the run going now", func(t *testing.T) {
+		clock := &fakeClock{now: start}
+		logs := &mockLogPrinter{}
+
+		s := New(
+			[]string{"bullhorn-contacts", "bullhorn-placements", "bullhorn-tearsheets"},
+			Interval(time.Hour),
+			nil,
+			clock,
+			logs,
+		)
+
+		runs := []string{}
+		ctx, stop := shutdown.WithStop(context.Background())
+
+		err := s.Run(ctx, func(_ context.Context, datasets []string) {
+			runs = append(runs, fmt.Sprintf("%s %s", clock.now.Format("15:04"), strings.Join(datasets, ",")))
+			clock.now = clock.now.Add(time.Minute)
+
+			switch len(runs) {
+			case 1:
+				// Triggered while running are coalesced into one run after it
+				s.Trigger([]string{"bullhorn-placements"})
+				s.Trigger([]string{"bullhorn-tearsheets", "bullhorn-placements"})
+				s.Trigger([]string{"bullhorn-unknown"})
+			case 2:
+				s.Trigger(nil)
+			case 3:
+				stop()
+			}
+		})
+		assert.ErrorIs(t, err, shutdown.ErrStopping)
+
+		assert.DeepEqual(t, runs, []string{
+			"10:00 bullhorn-contacts,bullhorn-placements,bullhorn-tearsheets",
+			"10:01 bullhorn-placements,bullhorn-tearsheets",
+			"10:02 bullhorn-contacts,bullhorn-placements,bullhorn-tearsheets",
+		})
+		assert.Equal(t, logs.msgs[6], "Running sync of bullhorn-placements, bullhorn-tearsheets\n")
+	})
+
+	t.Run("triggered datasets run again after their interval", func(t *testing.T) {
+		clock := &fakeClock{now: start}
+
+		s := New([]string{"bullhorn-contacts", "bullhorn-placements"}, Interval(time.Hour), nil, clock, &mockLogPrinter{})
+
+		runs := []string{}
+		ctx, stop := shutdown.WithStop(context.Background())
+
+		err := s.Run(ctx, func(_ context.Context, datasets []string) {
+			runs = append(runs, fmt.Sprintf("%s %s", clock.now.Format("15:04"), strings.Join(datasets, ",")))
+
+			// Each run takes a minute and the first one triggers a run of placements
+			clock.now = clock.now.Add(time.Minute)
+			if len(runs) == 1 {
+				clock.now = clock.now.Add(29 * time.Minute)
+				s.Trigger([]string{"bullhorn-placements"})
+			}
+
+			if len(runs) == 4 {
+				stop()
+			}
+		})
+		assert.ErrorIs(t, err, shutdown.ErrStopping)
+
+		assert.DeepEqual(t, runs, []string{
+			"10:00 bullhorn-contacts,bullhorn-placements",
+			"10:30 bullhorn-placements",
+			"11:30 bullhorn-contacts",
+			"11:31 bullhorn-placements",
+		})
+	})
+}
+
+func TestScheduler_LongestGap(t *testing.T) {
+	from := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	parse := func(spec string) Schedule {
+		s, err := Parse(spec)
+		assert.NilError(t, err)
+		return s
+	}
+
+	specs := []struct {
+		schedules map[string]Schedule
+		def       Schedule
+		want      time.Duration
+	}{
+		{def: parse("15m"), want: 15 * time.Minute},
+		{def: parse("0 6 * * *"), want: 24 * time.Hour},
+		// Friday 17:00 to Monday 9:00
+		{def: parse("0 9-17 * * 1-5"), want: 64 * time.Hour},
+		{def: parse("* * * * *"), want: time.Minute},
+		{def: parse("15m"), schedules: map[string]Schedule{"bullhorn-contacts": parse("0 0 1 * *")}, want: 31 * 24 * time.Hour},
+		{def: parse("0 0 30 2 *"), want: 0},
+	}
+
+	for _, spec := range specs {
+		s := New([]string{"bullhorn-contacts", "bullhorn-placements"}, spec.def, spec.schedules, &fakeClock{}, &mockLogPrinter{})
+		assert.Equal(t, s.LongestGap(from), spec.want, "%s %v", spec.def, spec.schedules)
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// After moves the clock on straight away and fires
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+type mockLogPrinter struct {
+	msgs []string
+}
+
+func (m *mockLogPrinter) Printf(format string, v ...interface{}) {
+	m.msgs = append(m.msgs, fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,66 @@
+package schedule
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SyncHandler triggers a sync on POST of the datasets in the dataset query
+// params, which can be repeated or comma separated, or of every dataset
+// without any. The token has to be sent as a bearer token. Each dataset
+// is resolved to its full name, unknown ones are rejected
+func (s *Scheduler) SyncHandler(token string, resolve func(string) (string, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !validToken(req, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		datasets := []string{}
+		for _, param := range req.URL.Query()["dataset"] {
+			for _, name := range strings.Split(param, ",") {
+				if strings.TrimSpace(name) == "" {
+					continue
+				}
+
+				d, err := resolve(name)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				datasets = append(datasets, d)
+			}
+		}
+
+		s.Trigger(datasets)
+
+		w.WriteHeader(http.StatusAccepted)
+		if len(datasets) == 0 {
+			fmt.Fprintln(w, "sync of every dataset triggered")
+			return
+		}
+
+		fmt.Fprintf(w, "sync of %s triggered\n", strings.Join(datasets, ", "))
+	})
+}
+
+func validToken(req *http.Request, token string) bool {
+	const prefix = "Bearer "
+
+	header := req.Header.Get("Authorization")
+	if token == "" || !strings.HasPrefix(header, prefix) {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, prefix)), []byte(token)) == 1
+}
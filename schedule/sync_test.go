@@ -0,0 +1,104 @@
+package schedule
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestScheduler_SyncHandler(t *testing.T) {
+	s := New([]string{"bullhorn-contacts", "bullhorn-placements"}, Interval(time.Hour), nil, &fakeClock{}, &mockLogPrinter{})
+
+	resolve := func(name string) (string, error) {
+		switch name {
+		case "contact", "bullhorn-contacts":
+			return "bullhorn-contacts", nil
+		case "placement":
+			return "bullhorn-placements", nil
+		}
+
+		return "", fmt.Errorf("unknown dataset %q", name)
+	}
+
+	server := httptest.NewServer(s.SyncHandler("secret", resolve))
+	defer server.Close()
+
+	do := func(method, query, token string) (int, string) {
+		req, err := http.NewRequest(method, server.URL+"/sync"+query, nil)
+		assert.NilError(t, err)
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NilError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		assert.NilError(t, err)
+
+		return resp.StatusCode, string(b)
+	}
+
+	triggered := func() []string {
+		return entryNames(s.takeTriggered())
+	}
+
+	t.Run("rejects requests other than POST", func(t *testing.T) {
+		code, _ := do(http.MethodGet, "", "secret")
+		assert.Equal(t, code, http.StatusMethodNotAllowed)
+		assert.DeepEqual(t, triggered(), []string{})
+	})
+
+	t.Run("rejects requests without the token", func(t *testing.T) {
+		code, _ := do(http.MethodPost, "", "")
+		assert.Equal(t, code, http.StatusUnauthorized)
+
+		code, _ = do(http.MethodPost, "", "wrong")
+		assert.Equal(t, code, http.StatusUnauthorized)
+		assert.DeepEqual(t, triggered(), []string{})
+	})
+
+	t.Run("rejects unknown datasets", func(t *testing.T) {
+		code, body := do(http.MethodPost, "?dataset=placement,jobs", "secret")
+		assert.Equal(t, code, http.StatusBadRequest)
+		assert.Equal(t, body, "unknown dataset \"jobs\"\n")
+		assert.DeepEqual(t, triggered(), []string{})
+	})
+
+	t.Run("triggers the datasets", func(t *testing.T) {
+		code, body := do(http.MethodPost, "?dataset=placement", "secret")
+		assert.Equal(t, code, http.StatusAccepted)
+		assert.Equal(t, body, "sync of bullhorn-placements triggered\n")
+		assert.DeepEqual(t, triggered(), []string{"bullhorn-placements"})
+
+		code, _ = do(http.MethodPost, "?dataset=contact,placement", "secret")
+		assert.Equal(t, code, http.StatusAccepted)
+		assert.DeepEqual(t, triggered(), []string{"bullhorn-contacts", "bullhorn-placements"})
+	})
+
+	t.Run("triggers every dataset without any", func(t *testing.T) {
+		code, body := do(http.MethodPost, "", "secret")
+		assert.Equal(t, code, http.StatusAccepted)
+		assert.Assert(t, strings.Contains(body, "every dataset"))
+		assert.DeepEqual(t, triggered(), []string{"bullhorn-contacts", "bullhorn-placements"})
+	})
+}
+
+func TestScheduler_SyncHandler_NoToken(t *testing.T) {
+	s := New([]string{"bullhorn-contacts"}, Interval(time.Hour), nil, &fakeClock{}, &mockLogPrinter{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/sync", nil)
+	req.Header.Set("Authorization", "Bearer ")
+
+	s.SyncHandler("", nil).ServeHTTP(rec, req)
+	assert.Equal(t, rec.Code, http.StatusUnauthorized)
+}
@@ -0,0 +1,51 @@
+//go:build !windows
+// +build !windows
+
+package shutdown
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNotify(t *testing.T) {
+	t.Run("stops on the first signal and cancels after the grace period", func(t *testing.T) {
+		ctx, cancel := Notify(context.Background(), 50*time.Millisecond, syscall.SIGUSR2)
+		defer cancel()
+
+		assert.NilError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR2))
+
+		select {
+		case <-Stopping(ctx):
+		case <-time.After(time.Second):
+			t.Fatal("expected to start stopping")
+		}
+
+		assert.NilError(t, ctx.Err())
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("expected the context to be cancelled after the grace period")
+		}
+	})
+
+	t.Run("cancels straight away on a second signal", func(t *testing.T) {
+		ctx, cancel := Notify(context.Background(), time.Hour, syscall.SIGUSR2)
+		defer cancel()
+
+		assert.NilError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR2))
+		<-Stopping(ctx)
+		assert.NilError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR2))
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("expected the context to be cancelled")
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// ErrStopping is returned by work which stopped early as the app is shutting down
+var ErrStopping = errors.New("shutting down")
+
+type stoppingKey struct{}
+
+// Notify returns a context which starts stopping on the first of the signals,
+// letting work finish what it's doing and stop at the next clean point such as
+// between batches. The context is only cancelled, aborting any requests still
+// in flight, once the grace period has passed or on a second signal
+func Notify(parent context.Context, grace time.Duration, signals ...os.Signal) (context.Context, context.CancelFunc) {
+	ch := make(chan os.Signal, 2)
+	signal.Notify(ch, signals...)
+
+	ctx, cancel := WithStop(parent)
+	hardCtx, hardCancel := context.WithCancel(ctx)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		select {
+		case <-ch:
+			cancel()
+		case <-hardCtx.Done():
+			return
+		}
+
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+
+		select {
+		case <-ch:
+		case <-timer.C:
+		case <-hardCtx.Done():
+		}
+
+		hardCancel()
+	}()
+
+	return hardCtx, hardCancel
+}
+
+// WithStop returns a context carrying a stop which is triggered by calling
+// the returned func, without cancelling the context itself
+func WithStop(parent context.Context) (context.Context, func()) {
+	stopping := make(chan struct{})
+	once := &sync.Once{}
+
+	return context.WithValue(parent, stoppingKey{}, (<-chan struct{})(stopping)), func() {
+		once.Do(func() { close(stopping) })
+	}
+}
+
+// Stopping returns a channel closed once the app starts shutting down,
+// for contexts without a stop it's the context's done channel
+func Stopping(ctx context.Context) <-chan struct{} {
+	if ch, ok := ctx.Value(stoppingKey{}).(<-chan struct{}); ok {
+		return ch
+	}
+
+	return ctx.Done()
+}
+
+// IsStopping returns true once the app has started shutting down
+// or the context is done, so no new work should be started
+func IsStopping(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+
+	select {
+	case <-Stopping(ctx):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,41 @@
+package shutdown
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestWithStop(t *testing.T) {
+	ctx, stop := WithStop(context.Background())
+	assert.Assert(t, !IsStopping(ctx))
+
+	stop()
+	stop()
+
+	assert.Assert(t, IsStopping(ctx))
+	assert.NilError(t, ctx.Err())
+
+	select {
+	case <-Stopping(ctx):
+	default:
+		t.Fatal("expected the stopping channel to be closed")
+	}
+}
+
+func TestIsStopping(t *testing.T) {
+	t.Run("is false for a context without a stop", func(t *testing.T) {
+		assert.Assert(t, !IsStopping(context.Background()))
+	})
+
+	t.Run("is true once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Assert(t, IsStopping(ctx))
+
+		ctx, _ = WithStop(ctx)
+		assert.Assert(t, IsStopping(ctx))
+	})
+}
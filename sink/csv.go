@@ -0,0 +1,88 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+)
+
+// CSV writes each dataset to its own CSV file in the directory, replacing
+// the file on every run. The header is the dataset field names and empty
+// values are left as empty cells
+type CSV struct {
+	dir string
+}
+
+// NewCSV creates the directory when it doesn't exist
+func NewCSV(dir string) (*CSV, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &CSV{dir: dir}, nil
+}
+
+func (c *CSV) String() string {
+	return CSVName
+}
+
+func (c *CSV) Prepare(context.Context, *geckoboard.Dataset) error {
+	return nil
+}
+
+func (c *CSV) Write(_ context.Context, dataset *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+	err := writeFile(c.dir, dataset.Name+".csv", func(w io.Writer) error {
+		return writeCSV(w, dataset, data)
+	})
+	if err != nil {
+		return geckoboard.AppendResult{}, err
+	}
+
+	return writtenResult(data), nil
+}
+
+func (c *CSV) Incremental() bool {
+	return false
+}
+
+// writeCSV writes a header of the dataset field names followed by the rows
+func writeCSV(w io.Writer, dataset *geckoboard.Dataset, data geckoboard.Data) error {
+	cols := columns(dataset)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(cols); err != nil {
+		return err
+	}
+
+	for _, row := range data {
+		record := make([]string, len(cols))
+		for i, k := range cols {
+			record[i] = csvCell(dataset.Fields[k], row[k])
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvCell(field geckoboard.Field, v interface{}) string {
+	switch val := fieldValue(field, v).(type) {
+	case string:
+		return val
+	case float64:
+		if field.Type == geckoboard.MoneyType {
+			return strconv.FormatFloat(val, 'f', 2, 64)
+		}
+
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+
+	return ""
+}
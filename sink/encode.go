@@ -0,0 +1,42 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is how rows are encoded when written out
+type Format string
+
+const (
+	CSVFormat       Format = "csv"
+	JSONFormat      Format = "json"
+	JSONLinesFormat Format = "jsonl"
+)
+
+// ParseFormat returns the format for the value, erroring for unknown ones
+func ParseFormat(v string) (Format, error) {
+	switch f := Format(strings.ToLower(v)); f {
+	case CSVFormat, JSONFormat, JSONLinesFormat:
+		return f, nil
+	}
+
+	return "", fmt.Errorf("unknown format %q, only csv, json and jsonl are valid", v)
+}
+
+// Encode writes the rows to the writer in the format following the
+// dataset schema the same way the file sinks do
+func Encode(w io.Writer, format Format, dataset *geckoboard.Dataset, data geckoboard.Data) error {
+	switch format {
+	case CSVFormat:
+		return writeCSV(w, dataset, data)
+	case JSONFormat:
+		return writeJSON(w, dataset, data)
+	case JSONLinesFormat:
+		return writeJSONLines(w, dataset, data)
+	}
+
+	return fmt.Errorf("unknown format %q", format)
+}
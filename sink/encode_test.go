@@ -0,0 +1,46 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	got, err := ParseFormat("JSON")
+	assert.NilError(t, err)
+	assert.Equal(t, got, JSONFormat)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err, `unknown format "xml", only csv, json and jsonl are valid`)
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("writes csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NilError(t, Encode(buf, CSVFormat, testDataset(), testData()[1:]))
+		assert.Equal(t, buf.String(), "id,created_at,hours,salary,start_date,status\n2,,8,,,\n")
+	})
+
+	t.Run("writes a json array", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NilError(t, Encode(buf, JSONFormat, testDataset(), testData()[1:]))
+		assert.Equal(t, buf.String(), "[\n"+
+			`  {"id":"2","created_at":null,"hours":8,"salary":null,"start_date":null,"status":null}`+"\n"+
+			"]\n")
+	})
+
+	t.Run("writes an empty json array when there are no rows", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NilError(t, Encode(buf, JSONFormat, testDataset(), geckoboard.Data{}))
+		assert.Equal(t, buf.String(), "[]\n")
+	})
+
+	t.Run("writes json lines", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NilError(t, Encode(buf, JSONLinesFormat, testDataset(), testData()[1:]))
+		assert.Equal(t, buf.String(), `{"id":"2","created_at":null,"hours":8,"salary":null,"start_date":null,"status":null}`+"\n")
+	})
+}
@@ -0,0 +1,113 @@
+package sink
+
+import (
+	"bufio"
+	"bullhorn-to-dataset/geckoboard"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// columns returns the dataset fields in the order they are written,
+// the unique by fields first and then the rest sorted by name
+func columns(dataset *geckoboard.Dataset) []string {
+	cols := []string{}
+	seen := map[string]bool{}
+
+	for _, k := range dataset.UniqueBy {
+		if _, ok := dataset.Fields[k]; ok && !seen[k] {
+			cols = append(cols, k)
+			seen[k] = true
+		}
+	}
+
+	rest := []string{}
+	for k := range dataset.Fields {
+		if !seen[k] {
+			rest = append(rest, k)
+		}
+	}
+
+	sort.Strings(rest)
+	return append(cols, rest...)
+}
+
+// fieldValue returns the row value as a string or float64 for the field
+// type, nil when it's empty. Money is converted back from minor units
+func fieldValue(field geckoboard.Field, v interface{}) interface{} {
+	v = geckoboard.Indirect(v)
+	if v == nil {
+		return nil
+	}
+
+	switch field.Type {
+	case geckoboard.NumberType, geckoboard.PercentType, geckoboard.DurationType, geckoboard.MoneyType:
+		n, ok := geckoboard.ToFloat(v)
+		if !ok {
+			return nil
+		}
+
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil
+		}
+
+		if field.Type == geckoboard.MoneyType {
+			return n / 100
+		}
+
+		return n
+	case geckoboard.DatetimeType:
+		if t, ok := v.(time.Time); ok {
+			return t.Format(time.RFC3339)
+		}
+	case geckoboard.DateType:
+		if t, ok := v.(time.Time); ok {
+			return t.Format(geckoboard.DateFormat)
+		}
+	}
+
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return nil
+}
+
+// writeFile writes to a temporary file first so readers
+// never see a file which is only partly written
+func writeFile(dir, name string, write func(io.Writer) error) error {
+	path := filepath.Join(dir, name)
+	tmp := path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if err := write(w); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func writtenResult(data geckoboard.Data) geckoboard.AppendResult {
+	return geckoboard.AppendResult{Batches: 1, Sent: 1, RecordsSent: len(data)}
+}
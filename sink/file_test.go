@@ -0,0 +1,104 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func testDataset() *geckoboard.Dataset {
+	return &geckoboard.Dataset{
+		Name: "bullhorn-placements",
+		Fields: map[string]geckoboard.Field{
+			"status":     {Name: "Status", Type: geckoboard.StringType, Optional: true},
+			"salary":     {Name: "Salary", Type: geckoboard.MoneyType, CurrencyCode: "USD", Optional: true},
+			"id":         {Name: "ID", Type: geckoboard.StringType},
+			"created_at": {Name: "Created at", Type: geckoboard.DatetimeType, Optional: true},
+			"start_date": {Name: "Start date", Type: geckoboard.DateType, Optional: true},
+			"hours":      {Name: "Hours", Type: geckoboard.NumberType, Optional: true},
+		},
+		UniqueBy: []string{"id"},
+	}
+}
+
+func testData() geckoboard.Data {
+	status := "Approved"
+	var missing *string
+
+	return geckoboard.Data{
+		{
+			"id":         "1",
+			"status":     &status,
+			"salary":     geckoboard.MoneyValue(52000.5),
+			"created_at": "2022-05-05T10:00:00Z",
+			"start_date": time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+			"hours":      7.5,
+			"unknown":    "not in the schema",
+		},
+		{
+			"id":     "2",
+			"status": missing,
+			"hours":  8,
+		},
+	}
+}
+
+func TestColumns(t *testing.T) {
+	assert.DeepEqual(t, columns(testDataset()), []string{"id", "created_at", "hours", "salary", "start_date", "status"})
+}
+
+func TestCSV_Write(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "output")
+
+	s, err := NewCSV(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, s.String(), "csv")
+	assert.Assert(t, !s.Incremental())
+	assert.NilError(t, s.Prepare(context.Background(), testDataset()))
+
+	result, err := s.Write(context.Background(), testDataset(), testData())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, geckoboard.AppendResult{Batches: 1, Sent: 1, RecordsSent: 2})
+
+	got, err := os.ReadFile(filepath.Join(dir, "bullhorn-placements.csv"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(got), "id,created_at,hours,salary,start_date,status\n"+
+		"1,2022-05-05T10:00:00Z,7.5,52000.50,2022-06-01,Approved\n"+
+		"2,,8,,,\n")
+
+	t.Run("replaces the file on the next run", func(t *testing.T) {
+		_, err := s.Write(context.Background(), testDataset(), geckoboard.Data{})
+		assert.NilError(t, err)
+
+		got, err := os.ReadFile(filepath.Join(dir, "bullhorn-placements.csv"))
+		assert.NilError(t, err)
+		assert.Equal(t, string(got), "id,created_at,hours,salary,start_date,status\n")
+
+		_, err = os.Stat(filepath.Join(dir, "bullhorn-placements.csv.tmp"))
+		assert.Assert(t, os.IsNotExist(err))
+	})
+}
+
+func TestJSONLines_Write(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "output")
+
+	s, err := NewJSONLines(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, s.String(), "jsonl")
+	assert.Assert(t, !s.Incremental())
+
+	result, err := s.Write(context.Background(), testDataset(), testData())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, geckoboard.AppendResult{Batches: 1, Sent: 1, RecordsSent: 2})
+
+	got, err := os.ReadFile(filepath.Join(dir, "bullhorn-placements.jsonl"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(got),
+		`{"id":"1","created_at":"2022-05-05T10:00:00Z","hours":7.5,"salary":52000.5,"start_date":"2022-06-01","status":"Approved"}`+"\n"+
+			`{"id":"2","created_at":null,"hours":8,"salary":null,"start_date":null,"status":null}`+"\n")
+}
@@ -0,0 +1,33 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+)
+
+// Geckoboard pushes the rows to Geckoboard datasets
+type Geckoboard struct {
+	client *geckoboard.Client
+}
+
+func NewGeckoboard(client *geckoboard.Client) *Geckoboard {
+	return &Geckoboard{client: client}
+}
+
+func (g *Geckoboard) String() string {
+	return GeckoboardName
+}
+
+// Prepare creates the dataset when it doesn't exist
+func (g *Geckoboard) Prepare(ctx context.Context, dataset *geckoboard.Dataset) error {
+	return g.client.DatasetService.FindOrCreate(ctx, dataset)
+}
+
+func (g *Geckoboard) Write(ctx context.Context, dataset *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+	return g.client.DatasetService.AppendData(ctx, dataset, data)
+}
+
+// Incremental is true as rows are appended and updated by the unique by fields
+func (g *Geckoboard) Incremental() bool {
+	return true
+}
@@ -0,0 +1,125 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// JSONLines writes each dataset to its own JSON Lines file in the directory,
+// replacing the file on every run. Each row is an object with every dataset
+// field in column order and null for empty values
+type JSONLines struct {
+	dir string
+}
+
+// NewJSONLines creates the directory when it doesn't exist
+func NewJSONLines(dir string) (*JSONLines, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &JSONLines{dir: dir}, nil
+}
+
+func (j *JSONLines) String() string {
+	return JSONLinesName
+}
+
+func (j *JSONLines) Prepare(context.Context, *geckoboard.Dataset) error {
+	return nil
+}
+
+func (j *JSONLines) Write(_ context.Context, dataset *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+	err := writeFile(j.dir, dataset.Name+".jsonl", func(w io.Writer) error {
+		return writeJSONLines(w, dataset, data)
+	})
+	if err != nil {
+		return geckoboard.AppendResult{}, err
+	}
+
+	return writtenResult(data), nil
+}
+
+func (j *JSONLines) Incremental() bool {
+	return false
+}
+
+// writeJSONLines writes each row as a JSON object on its own line
+func writeJSONLines(w io.Writer, dataset *geckoboard.Dataset, data geckoboard.Data) error {
+	cols := columns(dataset)
+
+	for _, row := range data {
+		obj, err := jsonObject(dataset, cols, row)
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(append(obj, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeJSON writes the rows as a JSON array with a row on each line
+func writeJSON(w io.Writer, dataset *geckoboard.Dataset, data geckoboard.Data) error {
+	cols := columns(dataset)
+	buf := &bytes.Buffer{}
+	buf.WriteByte('[')
+
+	for i, row := range data {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		obj, err := jsonObject(dataset, cols, row)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\n  ")
+		buf.Write(obj)
+	}
+
+	if len(data) > 0 {
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteString("]\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// jsonObject encodes the row by hand as a map would lose the column order
+func jsonObject(dataset *geckoboard.Dataset, cols []string, row geckoboard.DataRow) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+
+	for i, k := range cols {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := json.Marshal(fieldValue(dataset.Fields[k], row[k]))
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
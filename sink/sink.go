@@ -0,0 +1,46 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	GeckoboardName = "geckoboard"
+	CSVName        = "csv"
+	JSONLinesName  = "jsonl"
+	WebhookName    = "webhook"
+)
+
+// Sink is somewhere the rows of each dataset are written to
+type Sink interface {
+	fmt.Stringer
+
+	// Prepare readies the sink for the dataset before any data is written
+	Prepare(context.Context, *geckoboard.Dataset) error
+	Write(context.Context, *geckoboard.Dataset, geckoboard.Data) (geckoboard.AppendResult, error)
+
+	// Incremental is true when the sink keeps the rows written by earlier
+	// runs, so only the rows which changed need to be written again
+	Incremental() bool
+}
+
+// ParseNames returns the sink names lowercased, erroring for unknown ones
+func ParseNames(names []string) ([]string, error) {
+	parsed := []string{}
+
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+
+		switch n {
+		case GeckoboardName, CSVName, JSONLinesName, WebhookName:
+			parsed = append(parsed, n)
+		default:
+			return nil, fmt.Errorf("unknown sink %q, only geckoboard, csv, jsonl and webhook are valid", n)
+		}
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,16 @@
+package sink
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseNames(t *testing.T) {
+	got, err := ParseNames([]string{"Geckoboard", " csv", "jsonl", "webhook"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, []string{"geckoboard", "csv", "jsonl", "webhook"})
+
+	_, err = ParseNames([]string{"parquet"})
+	assert.Error(t, err, `unknown sink "parquet", only geckoboard, csv, jsonl and webhook are valid`)
+}
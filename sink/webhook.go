@@ -0,0 +1,258 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"bullhorn-to-dataset/shutdown"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SignatureHeader holds the HMAC SHA-256 of the request body when a
+// webhook secret is set, formatted like sha256=<hex digest>
+const SignatureHeader = "X-Signature-256"
+
+const defaultWebhookBatchSize = 500
+
+// RetryPolicy is how many times a batch is tried and how long to wait
+// between tries, the wait doubles after each one
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// WebhookOptions configures where and how the webhook sink posts data
+type WebhookOptions struct {
+	URL     string
+	Headers map[string]string
+	// Secret signs the body of each request when set
+	Secret    string
+	BatchSize int
+	Retry     RetryPolicy
+	Timeout   time.Duration
+}
+
+// WebhookError is a response from the webhook which wasn't a success
+type WebhookError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *WebhookError) Error() string {
+	return fmt.Sprintf("webhook responded with status %d: %s", e.StatusCode, e.Body)
+}
+
+// webhookPayload is the body posted for each batch of rows,
+// every batch includes the schema so it can be handled on its own
+type webhookPayload struct {
+	Dataset string              `json:"dataset"`
+	Schema  *geckoboard.Dataset `json:"schema"`
+	Batch   int                 `json:"batch"`
+	Batches int                 `json:"batches"`
+	Data    geckoboard.Data     `json:"data"`
+}
+
+// Webhook posts the schema and rows of each dataset as JSON to a URL
+type Webhook struct {
+	client  *http.Client
+	options WebhookOptions
+	sleep   func(context.Context, time.Duration) error
+}
+
+func NewWebhook(options WebhookOptions) (*Webhook, error) {
+	u, err := url.Parse(options.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook url %q, expected an http or https url", options.URL)
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultWebhookBatchSize
+	}
+
+	if options.Retry.MaxAttempts <= 0 {
+		options.Retry.MaxAttempts = 1
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = 30 * time.Second
+	}
+
+	return &Webhook{
+		client:  &http.Client{Timeout: options.Timeout},
+		options: options,
+		sleep:   sleep,
+	}, nil
+}
+
+func (w *Webhook) String() string {
+	return WebhookName
+}
+
+func (w *Webhook) Prepare(context.Context, *geckoboard.Dataset) error {
+	return nil
+}
+
+// Write posts the rows in batches stopping at the first batch which
+// still fails after retrying, the batches sent before it are kept
+func (w *Webhook) Write(ctx context.Context, dataset *geckoboard.Dataset, data geckoboard.Data) (geckoboard.AppendResult, error) {
+	size := w.options.BatchSize
+	batches := (len(data) + size - 1) / size
+	result := geckoboard.AppendResult{Batches: batches}
+
+	for i := 0; i < batches; i++ {
+		start := i * size
+		end := start + size
+		if end > len(data) {
+			end = len(data)
+		}
+
+		if shutdown.IsStopping(ctx) {
+			return result, fmt.Errorf("stopped before batch %d: %w", i+1, shutdown.ErrStopping)
+		}
+
+		payload := webhookPayload{
+			Dataset: dataset.Name,
+			Schema:  dataset,
+			Batch:   i + 1,
+			Batches: batches,
+			Data:    data[start:end],
+		}
+
+		if err := w.post(ctx, payload); err != nil {
+			result.Failed = append(result.Failed, geckoboard.BatchError{
+				Batch: i,
+				Start: start,
+				End:   end,
+				Err:   err,
+			})
+
+			return result, result.Err()
+		}
+
+		result.Sent++
+		result.RecordsSent += end - start
+	}
+
+	return result, nil
+}
+
+// Incremental is false as the receiver is sent every row on every run
+func (w *Webhook) Incremental() bool {
+	return false
+}
+
+// post sends the payload retrying network errors, rate limiting
+// and server errors until the retry policy's attempts run out
+func (w *Webhook) post(ctx context.Context, payload webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.options.Retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err = w.send(ctx, body)
+		// The context being done isn't going to change by retrying
+		if err == nil || ctx.Err() != nil || !retryable(err) || attempt >= w.options.Retry.MaxAttempts {
+			return err
+		}
+
+		if err := w.sleep(ctx, backoff); err != nil {
+			return err
+		}
+
+		backoff *= 2
+	}
+}
+
+func (w *Webhook) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.options.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.options.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if w.options.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.options.Secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return &WebhookError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+}
+
+// Sign returns the signature of the body sent in the signature header,
+// receivers compute the same from the raw body to check it
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseHeaders parses headers formatted as Name: value
+func ParseHeaders(headers []string) (map[string]string, error) {
+	parsed := map[string]string{}
+
+	for _, h := range headers {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid header %q, expected Name: value", h)
+		}
+
+		parsed[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return parsed, nil
+}
+
+func retryable(err error) bool {
+	var werr *WebhookError
+	if errors.As(err, &werr) {
+		return werr.StatusCode == http.StatusTooManyRequests || werr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-shutdown.Stopping(ctx):
+		return shutdown.ErrStopping
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,191 @@
+package sink
+
+import (
+	"bullhorn-to-dataset/geckoboard"
+	"bullhorn-to-dataset/shutdown"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, b)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+
+	w.WriteHeader(status)
+	w.Write([]byte("response body\n"))
+}
+
+func newTestWebhook(t *testing.T, receiver *webhookReceiver, options WebhookOptions) (*Webhook, *[]time.Duration) {
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	options.URL = server.URL + "/ingest"
+
+	w, err := NewWebhook(options)
+	assert.NilError(t, err)
+
+	waits := &[]time.Duration{}
+	w.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+
+	return w, waits
+}
+
+func TestNewWebhook(t *testing.T) {
+	_, err := NewWebhook(WebhookOptions{URL: "example.com/ingest"})
+	assert.Error(t, err, `invalid webhook url "example.com/ingest", expected an http or https url`)
+
+	w, err := NewWebhook(WebhookOptions{URL: "https://example.com/ingest"})
+	assert.NilError(t, err)
+	assert.Equal(t, w.String(), "webhook")
+	assert.Assert(t, !w.Incremental())
+	assert.Equal(t, w.options.BatchSize, 500)
+	assert.Equal(t, w.options.Retry.MaxAttempts, 1)
+}
+
+func TestWebhook_Write(t *testing.T) {
+	dataset := &geckoboard.Dataset{
+		Name: "bullhorn-placements",
+		Fields: map[string]geckoboard.Field{
+			"id": {Name: "ID", Type: geckoboard.StringType},
+		},
+		UniqueBy: []string{"id"},
+	}
+	data := geckoboard.Data{{"id": "1"}, {"id": "2"}, {"id": "3"}}
+
+	t.Run("posts the schema and rows in batches", func(t *testing.T) {
+		receiver := &webhookReceiver{}
+		w, _ := newTestWebhook(t, receiver, WebhookOptions{
+			Headers:   map[string]string{"Authorization": "Bearer token"},
+			BatchSize: 2,
+		})
+
+		result, err := w.Write(context.Background(), dataset, data)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, result, geckoboard.AppendResult{Batches: 2, Sent: 2, RecordsSent: 3})
+
+		assert.Equal(t, len(receiver.requests), 2)
+
+		req := receiver.requests[0]
+		assert.Equal(t, req.Method, http.MethodPost)
+		assert.Equal(t, req.URL.Path, "/ingest")
+		assert.Equal(t, req.Header.Get("Content-Type"), "application/json")
+		assert.Equal(t, req.Header.Get("Authorization"), "Bearer token")
+		assert.Equal(t, req.Header.Get(SignatureHeader), "")
+
+		assert.Equal(t, string(receiver.bodies[0]), `{"dataset":"bullhorn-placements",`+
+			`"schema":{"id":"bullhorn-placements","fields":{"id":{"type":"string","name":"ID","optional":false}},"unique_by":["id"]},`+
+			`"batch":1,"batches":2,"data":[{"id":"1"},{"id":"2"}]}`)
+
+		payload := webhookPayload{}
+		assert.NilError(t, json.Unmarshal(receiver.bodies[1], &payload))
+		assert.Equal(t, payload.Batch, 2)
+		assert.DeepEqual(t, payload.Data, geckoboard.Data{{"id": "3"}})
+	})
+
+	t.Run("signs the body with the secret", func(t *testing.T) {
+		receiver := &webhookReceiver{}
+		w, _ := newTestWebhook(t, receiver, WebhookOptions{Secret: "s3cret"})
+
+		_, err := w.Write(context.Background(), dataset, data)
+		assert.NilError(t, err)
+
+		body := receiver.bodies[0]
+		assert.Equal(t, receiver.requests[0].Header.Get(SignatureHeader), Sign("s3cret", body))
+		assert.Equal(t, Sign("key", []byte("body")), "sha256=515aae133b435d4000956731f68ae5cf5eb85d4f0dc6a546d2bfcd3595ec1ae1")
+	})
+
+	t.Run("retries server errors with a doubling backoff", func(t *testing.T) {
+		receiver := &webhookReceiver{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+		w, waits := newTestWebhook(t, receiver, WebhookOptions{
+			Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Second},
+		})
+
+		result, err := w.Write(context.Background(), dataset, data)
+		assert.NilError(t, err)
+		assert.Equal(t, result.Sent, 1)
+		assert.Equal(t, len(receiver.requests), 3)
+		assert.DeepEqual(t, *waits, []time.Duration{time.Second, 2 * time.Second})
+	})
+
+	t.Run("returns the batch error once the attempts run out", func(t *testing.T) {
+		receiver := &webhookReceiver{statuses: []int{500, 500, 500}}
+		w, _ := newTestWebhook(t, receiver, WebhookOptions{
+			BatchSize: 2,
+			Retry:     RetryPolicy{MaxAttempts: 2},
+		})
+
+		result, err := w.Write(context.Background(), dataset, data)
+		assert.Error(t, err, "1 of 2 batches failed: batch 1 (rows 1-2): webhook responded with status 500: response body")
+		assert.Equal(t, result.Sent, 0)
+		assert.Equal(t, len(receiver.requests), 2)
+	})
+
+	t.Run("stops between batches when shutting down", func(t *testing.T) {
+		receiver := &webhookReceiver{}
+		w, _ := newTestWebhook(t, receiver, WebhookOptions{BatchSize: 2})
+
+		ctx, stop := shutdown.WithStop(context.Background())
+		w.client.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			stop()
+			return http.DefaultTransport.RoundTrip(req)
+		})
+
+		result, err := w.Write(ctx, dataset, data)
+		assert.Error(t, err, "stopped before batch 2: shutting down")
+		assert.Equal(t, result.Sent, 1)
+		assert.Equal(t, len(receiver.requests), 1)
+	})
+
+	t.Run("doesn't retry client errors", func(t *testing.T) {
+		receiver := &webhookReceiver{statuses: []int{http.StatusBadRequest}}
+		w, _ := newTestWebhook(t, receiver, WebhookOptions{
+			Retry: RetryPolicy{MaxAttempts: 3},
+		})
+
+		_, err := w.Write(context.Background(), dataset, data)
+		assert.ErrorContains(t, err, "webhook responded with status 400")
+		assert.Equal(t, len(receiver.requests), 1)
+	})
+}
+
+func TestParseHeaders(t *testing.T) {
+	got, err := ParseHeaders([]string{"Authorization: Bearer a:b", "X-Source:bullhorn"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, map[string]string{"Authorization": "Bearer a:b", "X-Source": "bullhorn"})
+
+	_, err = ParseHeaders([]string{"no-colon"})
+	assert.Error(t, err, `invalid header "no-colon", expected Name: value`)
+}